@@ -0,0 +1,556 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"jgschema/diff"
+	"jgschema/drift"
+	"jgschema/graphql"
+	"jgschema/infer"
+	"jgschema/jsonutils"
+	"jgschema/sdl"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// runConvert handles the convert subcommand, writing the generated GraphQL schema to stdout or the path passed to -o.
+func runConvert(args []string) int {
+	flags := flag.NewFlagSet("convert", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: jgschema convert [flags] <schema.json> [schema.json...]")
+		flags.PrintDefaults()
+	}
+	output := flags.String("o", "", "path to write the GraphQL schema to (defaults to stdout)")
+	conversion := addConversionFlags(flags)
+
+	paths, code := parseArgs(flags, args)
+	if code != exitOK || len(paths) == 0 {
+		return code
+	}
+
+	opts, code := conversion.options(paths)
+	if code != exitOK {
+		return code
+	}
+
+	schemas, err := transformAll(paths, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	if *output != "" {
+		if err := graphql.GenerateToFile(schemas, *output, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing graphql schema to %q: %v\n", *output, err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	generated, err := graphql.Generate(schemas)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	fmt.Println(generated)
+	return exitOK
+}
+
+// conversionFlags holds the flags configuring how JSON schemas are converted into GraphQL, shared by the convert and
+// check subcommands.
+type conversionFlags struct {
+	rootName      *string
+	rootFrom      *string
+	unions        *bool
+	multiType     *string
+	nameConflicts *string
+	inputs        *bool
+	maps          *string
+	mapScalar     *string
+	items         *string
+	minItems      *bool
+	tuples        *string
+	constraints   *bool
+	typeMap       *string
+	warnFormats   *bool
+	enumValues    *string
+}
+
+// addConversionFlags defines the conversion flags on flags.
+func addConversionFlags(flags *flag.FlagSet) *conversionFlags {
+	return &conversionFlags{
+		rootName:      flags.String("root", "", "custom type name for the root schema (only valid with a single input)"),
+		rootFrom:      flags.String("root-from", "file", "derive the root type name from the schema's \"file\" name or \"title\""),
+		unions:        flags.Bool("unions", false, "turn oneOf and anyOf into GraphQL unions instead of merging them into the parent"),
+		multiType:     flags.String("multi-type", "error", "handling of properties with several non-null types: \"error\" or \"union\""),
+		nameConflicts: flags.String("name-conflicts", "prefix", "handling of different types sharing a name: \"prefix\" (with the parent type), \"number\" or \"error\""),
+		inputs:        flags.Bool("inputs", false, "add an input type for every object type (schemas can override this with \"x-graphql-input\")"),
+		maps:          flags.String("maps", "scalar", "representation of free-form objects and additionalProperties: \"scalar\" or \"key-value\" (a list of key value pairs)"),
+		mapScalar:     flags.String("map-scalar", "JSON", "name of the custom scalar used for free-form objects"),
+		items:         flags.String("items", "nullable", "nullability of list items: \"nullable\", or \"schema\" to make items non-null unless their schema allows null"),
+		minItems:      flags.Bool("min-items", false, "make lists with a minItems of at least one non-null"),
+		tuples:        flags.String("tuples", "object", "handling of tuples whose positions have different schemas: \"object\" (a field per position) or \"union\" (a list of a union)"),
+		constraints:   flags.Bool("constraints", false, "add @constraint directives for validation keywords such as minLength, pattern or maximum"),
+		typeMap:       flags.String("type-map", "", "path to a JSON file overriding GraphQL types by JSON type, format, $ref or property path"),
+		warnFormats:   flags.Bool("warn-formats", false, "print a warning for every \"format\" without a matching custom scalar"),
+		enumValues:    flags.String("enum-values", "sanitize", "handling of enum values that aren't valid GraphQL names: \"sanitize\", \"prefix\" or \"error\""),
+	}
+}
+
+// options validates the parsed conversion flags for the schemas at paths and turns them into graphql.Options.
+func (c *conversionFlags) options(paths []string) (graphql.Options, int) {
+	if *c.rootName != "" && len(paths) > 1 {
+		fmt.Fprintln(os.Stderr, "-root can only be used with a single input schema")
+		return graphql.Options{}, exitUsage
+	}
+
+	opts := graphql.Options{RootTypeName: *c.rootName, InputTypes: *c.inputs, MapScalar: *c.mapScalar, MinItemsNonNull: *c.minItems, Constraints: *c.constraints}
+	if *c.unions {
+		opts.UnionMode = graphql.UnionsAsTypes
+	}
+
+	switch *c.rootFrom {
+	case "file":
+		opts.RootNameSource = graphql.RootNameFromFile
+	case "title":
+		opts.RootNameSource = graphql.RootNameFromTitle
+	default:
+		fmt.Fprintf(os.Stderr, "invalid -root-from value %q, must be \"file\" or \"title\"\n", *c.rootFrom)
+		return graphql.Options{}, exitUsage
+	}
+
+	switch *c.enumValues {
+	case "sanitize":
+		opts.EnumValueStrategy = graphql.EnumValuesSanitize
+	case "prefix":
+		opts.EnumValueStrategy = graphql.EnumValuesPrefix
+	case "error":
+		opts.EnumValueStrategy = graphql.EnumValuesError
+	default:
+		fmt.Fprintf(os.Stderr, "invalid -enum-values value %q, must be \"sanitize\", \"prefix\" or \"error\"\n", *c.enumValues)
+		return graphql.Options{}, exitUsage
+	}
+
+	switch *c.multiType {
+	case "error":
+		opts.MultiTypeStrategy = graphql.MultiTypeError
+	case "union":
+		opts.MultiTypeStrategy = graphql.MultiTypeUnion
+	default:
+		fmt.Fprintf(os.Stderr, "invalid -multi-type value %q, must be \"error\" or \"union\"\n", *c.multiType)
+		return graphql.Options{}, exitUsage
+	}
+
+	switch *c.maps {
+	case "scalar":
+		opts.MapStrategy = graphql.MapsAsScalar
+	case "key-value":
+		opts.MapStrategy = graphql.MapsAsKeyValueList
+	default:
+		fmt.Fprintf(os.Stderr, "invalid -maps value %q, must be \"scalar\" or \"key-value\"\n", *c.maps)
+		return graphql.Options{}, exitUsage
+	}
+
+	switch *c.items {
+	case "nullable":
+		opts.ItemNullability = graphql.ItemsNullable
+	case "schema":
+		opts.ItemNullability = graphql.ItemsFromSchema
+	default:
+		fmt.Fprintf(os.Stderr, "invalid -items value %q, must be \"nullable\" or \"schema\"\n", *c.items)
+		return graphql.Options{}, exitUsage
+	}
+
+	switch *c.tuples {
+	case "object":
+		opts.TupleStrategy = graphql.TuplesAsObjects
+	case "union":
+		opts.TupleStrategy = graphql.TuplesAsUnionLists
+	default:
+		fmt.Fprintf(os.Stderr, "invalid -tuples value %q, must be \"object\" or \"union\"\n", *c.tuples)
+		return graphql.Options{}, exitUsage
+	}
+
+	switch *c.nameConflicts {
+	case "prefix":
+		opts.NameConflictStrategy = graphql.NameConflictPrefixParent
+	case "number":
+		opts.NameConflictStrategy = graphql.NameConflictNumber
+	case "error":
+		opts.NameConflictStrategy = graphql.NameConflictError
+	default:
+		fmt.Fprintf(os.Stderr, "invalid -name-conflicts value %q, must be \"prefix\", \"number\" or \"error\"\n", *c.nameConflicts)
+		return graphql.Options{}, exitUsage
+	}
+
+	opts.OnRename = func(rename graphql.Rename) {
+		fmt.Fprintf(os.Stderr, "warning: renamed type %q nested in %q to %q to avoid a name conflict\n", rename.Original, rename.Parent, rename.Renamed)
+	}
+
+	if *c.typeMap != "" {
+		mapping, err := loadTypeMapping(*c.typeMap)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return graphql.Options{}, exitError
+		}
+		opts.TypeMapping = mapping
+	}
+
+	opts.OnSkippedInputField = func(typeName string, field graphql.Field) {
+		required := ""
+		if field.Required {
+			required = "required "
+		}
+		fmt.Fprintf(os.Stderr, "warning: left %sfield %q out of the input type of %q, since its type %q is a union and unions can't be used in input types\n", required, field.Name, typeName, field.Type)
+	}
+
+	if *c.warnFormats {
+		opts.OnUnknownFormat = func(field, format string) {
+			fmt.Fprintf(os.Stderr, "warning: unknown format %q on field %q, falling back to its JSON type\n", format, field)
+		}
+	}
+	return opts, exitOK
+}
+
+// runCheck handles the check subcommand, which runs the full conversion without writing any output. With -graphql,
+// the generated GraphQL schema is also compared against a committed one, printing the differences when they don't
+// match.
+func runCheck(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: jgschema check [flags] <schema.json> [schema.json...]")
+		flags.PrintDefaults()
+	}
+	committed := flags.String("graphql", "", "path to a committed GraphQL schema the generated one must match")
+	strictOrder := flags.Bool("strict-order", false, "with -graphql, fail when types, fields, enum values, union members or interfaces are in a different order")
+	strictWhitespace := flags.Bool("strict-whitespace", false, "with -graphql, compare whitespace within descriptions exactly")
+	ignoreDescriptions := flags.Bool("ignore-descriptions", false, "with -graphql, leave descriptions out of the comparison")
+	conversion := addConversionFlags(flags)
+
+	paths, code := parseArgs(flags, args)
+	if code != exitOK || len(paths) == 0 {
+		return code
+	}
+
+	opts, code := conversion.options(paths)
+	if code != exitOK {
+		return code
+	}
+
+	schemas, err := transformAll(paths, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	if _, err := graphql.Generate(schemas); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	if *committed == "" {
+		return exitOK
+	}
+
+	result, err := drift.CheckFile(schemas, *committed, drift.Options{
+		StrictOrder:        *strictOrder,
+		StrictWhitespace:   *strictWhitespace,
+		IgnoreDescriptions: *ignoreDescriptions,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	if result != "" {
+		fmt.Print(result)
+		fmt.Fprintf(os.Stderr, "%q does not match the graphql schema generated from the JSON schemas, regenerate it with \"jgschema convert\"\n", *committed)
+		return exitDrift
+	}
+
+	return exitOK
+}
+
+// runReverse handles the reverse subcommand, writing the JSON schema converted from one or more GraphQL schemas to
+// stdout or the path passed to -o.
+func runReverse(args []string) int {
+	flags := flag.NewFlagSet("reverse", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: jgschema reverse [flags] <schema.graphql> [schema.graphql...]")
+		flags.PrintDefaults()
+	}
+	output := flags.String("o", "", "path to write the JSON schema to (defaults to stdout)")
+	root := flags.String("root", "", "name of the type written at the root of the JSON schema, with every other type under $defs")
+
+	paths, code := parseArgs(flags, args)
+	if code != exitOK || len(paths) == 0 {
+		return code
+	}
+
+	var schemas []graphql.Schema
+	for _, path := range paths {
+		parsed, err := sdl.ParseFile(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		schemas = append(schemas, parsed...)
+	}
+
+	opts := sdl.Options{Root: *root}
+	if *output != "" {
+		if err := sdl.ToJSONSchemaFile(schemas, opts, *output, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing json schema to %q: %v\n", *output, err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	document, err := sdl.ToJSONSchema(schemas, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	contents, err := json.MarshalIndent(document, "", "    ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error marshaling json schema: %v\n", err)
+		return exitError
+	}
+
+	fmt.Println(string(contents))
+	return exitOK
+}
+
+// runInfer handles the infer subcommand, which merges sample JSON payloads into a JSON schema and writes the GraphQL
+// schema generated from it to stdout or the path passed to -o.
+func runInfer(args []string) int {
+	flags := flag.NewFlagSet("infer", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: jgschema infer [flags] <sample.json> [sample.json...]")
+		flags.PrintDefaults()
+	}
+	output := flags.String("o", "", "path to write the GraphQL schema to (defaults to stdout)")
+	rootName := flags.String("root", "", "custom type name for the root schema (defaults to the name of the first sample file)")
+	schemaOutput := flags.String("schema", "", "path to also write the inferred JSON schema to")
+
+	paths, code := parseArgs(flags, args)
+	if code != exitOK || len(paths) == 0 {
+		return code
+	}
+
+	title := *rootName
+	if title == "" {
+		title = strings.TrimSuffix(filepath.Base(paths[0]), filepath.Ext(paths[0]))
+	}
+
+	jsonSchema, err := infer.InferFiles(title, paths...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error inferring JSON schema: %v\n", err)
+		return exitError
+	}
+
+	if *schemaOutput != "" {
+		contents, err := json.MarshalIndent(jsonSchema, "", "    ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error marshaling json schema: %v\n", err)
+			return exitError
+		}
+
+		if err := os.WriteFile(*schemaOutput, append(contents, '\n'), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing json schema to %q: %v\n", *schemaOutput, err)
+			return exitError
+		}
+	}
+
+	opts := graphql.Options{RootTypeName: *rootName, RootNameSource: graphql.RootNameFromTitle}
+	schemas, err := graphql.TransformWithOptions(jsonSchema, "", opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error transforming the inferred JSON schema into a graphql schema: %v\n", err)
+		return exitError
+	}
+
+	if *output != "" {
+		if err := graphql.GenerateToFile(schemas, *output, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing graphql schema to %q: %v\n", *output, err)
+			return exitError
+		}
+		return exitOK
+	}
+
+	generated, err := graphql.Generate(schemas)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	fmt.Println(generated)
+	return exitOK
+}
+
+// runDiff handles the diff subcommand, which prints the changes between an old and a new version of a schema along
+// with their severity. Each version is either a GraphQL schema or a JSON schema, converted with the conversion flags.
+func runDiff(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: jgschema diff [flags] <old.graphql|old.json> <new.graphql|new.json>")
+		flags.PrintDefaults()
+	}
+	jsonOutput := flags.Bool("json", false, "print the changes as a JSON report")
+	failOn := flags.String("fail-on", "breaking", "exit with a non-zero code when a change is at least this severe: \"breaking\", \"dangerous\" or \"none\"")
+	conversion := addConversionFlags(flags)
+
+	paths, code := parseArgs(flags, args)
+	if code != exitOK || len(paths) == 0 {
+		return code
+	}
+
+	if len(paths) != 2 {
+		fmt.Fprintln(os.Stderr, "diff takes exactly two schema paths, the old and the new version")
+		return exitUsage
+	}
+
+	failSeverities := map[diff.Severity]bool{}
+	switch *failOn {
+	case "breaking":
+		failSeverities[diff.Breaking] = true
+	case "dangerous":
+		failSeverities[diff.Breaking] = true
+		failSeverities[diff.Dangerous] = true
+	case "none":
+	default:
+		fmt.Fprintf(os.Stderr, "invalid -fail-on value %q, must be \"breaking\", \"dangerous\" or \"none\"\n", *failOn)
+		return exitUsage
+	}
+
+	// Without -root, JSON schemas are named after their file, which the new version usually doesn't share with the
+	// old one. Its root is given the name of the old root instead, so that it isn't reported as removed and added.
+	var rootName string
+	versions := make([][]graphql.Schema, 0, len(paths))
+	for _, path := range paths {
+		switch filepath.Ext(path) {
+		case ".graphql", ".graphqls", ".gql":
+			schemas, err := sdl.ParseFile(path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return exitError
+			}
+			versions = append(versions, schemas)
+		default:
+			opts, code := conversion.options([]string{path})
+			if code != exitOK {
+				return code
+			}
+
+			if opts.RootTypeName == "" && opts.RootNameSource == graphql.RootNameFromFile {
+				opts.RootTypeName = rootName
+			}
+
+			schemas, err := transformAll([]string{path}, opts)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				return exitError
+			}
+
+			if rootName == "" && len(schemas) > 0 {
+				rootName = schemas[0].TypeName
+			}
+			versions = append(versions, schemas)
+		}
+	}
+
+	changes, err := diff.Compare(versions[0], versions[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	report := diff.NewReport(changes)
+	if *jsonOutput {
+		contents, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error marshaling changes: %v\n", err)
+			return exitError
+		}
+		fmt.Println(string(contents))
+	} else {
+		for _, change := range report.Changes {
+			fmt.Printf("[%s] %s\n", change.Severity, change.Message)
+		}
+		fmt.Printf("%d breaking, %d dangerous and %d safe changes\n", report.Breaking, report.Dangerous, report.Safe)
+	}
+
+	for _, change := range report.Changes {
+		if failSeverities[change.Severity] {
+			return exitChanges
+		}
+	}
+
+	return exitOK
+}
+
+// parseArgs parses the flags for a subcommand and returns the remaining positional arguments as schema paths.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, int) {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil, exitOK
+		}
+		return nil, exitUsage
+	}
+
+	if flags.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "at least one input schema path is required")
+		flags.Usage()
+		return nil, exitUsage
+	}
+
+	return flags.Args(), exitOK
+}
+
+// loadTypeMapping reads a type mapping configuration file, a JSON object with optional "types", "formats", "refs" and
+// "paths" objects mapping to GraphQL type names.
+func loadTypeMapping(path string) (graphql.TypeMapping, error) {
+	var mapping graphql.TypeMapping
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return mapping, fmt.Errorf("error reading type mapping %q: %w", path, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&mapping); err != nil {
+		return mapping, fmt.Errorf("error parsing type mapping %q: %w", path, err)
+	}
+
+	return mapping, nil
+}
+
+// transformAll reads and transforms every schema in paths, returning the combined list of GraphQL schemas. Types
+// generated from more than one schema, such as a shared definition, are only listed once.
+func transformAll(paths []string, opts graphql.Options) ([]graphql.Schema, error) {
+	var all []graphql.Schema
+	for _, path := range paths {
+		jsonSchema, err := jsonutils.ReadSchema(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading JSON schema %q: %w", path, err)
+		}
+
+		schemas, err := graphql.TransformWithOptions(jsonSchema, path, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error transforming %q into a graphql schema: %w", path, err)
+		}
+
+		all, err = graphql.Merge(all, schemas)
+		if err != nil {
+			return nil, fmt.Errorf("error merging %q with the previous schemas: %w", path, err)
+		}
+	}
+
+	return all, nil
+}
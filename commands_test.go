@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	type test struct {
+		description string
+		args        []string
+		wantCode    int
+	}
+
+	schemaTestDir := "./graphql/test_data/jsonschema"
+	simpleSchema := fmt.Sprintf("%s/simple-schema.json", schemaTestDir)
+	tests := []test{
+		{
+			description: "should fail without a command",
+			wantCode:    exitUsage,
+		},
+		{
+			description: "should fail on an unknown command",
+			args:        []string{"unknown"},
+			wantCode:    exitUsage,
+		},
+		{
+			description: "should print the version",
+			args:        []string{"version"},
+			wantCode:    exitOK,
+		},
+		{
+			description: "should fail without an input schema",
+			args:        []string{"check"},
+			wantCode:    exitUsage,
+		},
+		{
+			description: "should fail on an unknown flag",
+			args:        []string{"check", "-unknown", simpleSchema},
+			wantCode:    exitUsage,
+		},
+		{
+			description: "should fail on an invalid flag value",
+			args:        []string{"check", "-maps", "unknown", simpleSchema},
+			wantCode:    exitUsage,
+		},
+		{
+			description: "should fail on -root with several input schemas",
+			args:        []string{"check", "-root", "Root", simpleSchema, simpleSchema},
+			wantCode:    exitUsage,
+		},
+		{
+			description: "should fail on a missing input schema",
+			args:        []string{"check", "./test_data/missing.json"},
+			wantCode:    exitError,
+		},
+		{
+			description: "should fail on a missing type mapping file",
+			args:        []string{"check", "-type-map", "./test_data/missing.json", simpleSchema},
+			wantCode:    exitError,
+		},
+		{
+			description: "should fail on an invalid type mapping file",
+			args:        []string{"check", "-type-map", "./test_data/invalid-type-map.json", simpleSchema},
+			wantCode:    exitError,
+		},
+		{
+			description: "should check a schema that can be converted",
+			args:        []string{"check", simpleSchema},
+			wantCode:    exitOK,
+		},
+		{
+			description: "should report a committed schema that doesn't match",
+			args:        []string{"check", "-graphql", "./test_data/mutual.graphql", simpleSchema},
+			wantCode:    exitDrift,
+		},
+		{
+			description: "should find no changes between the same JSON schema at different paths",
+			args:        []string{"diff", simpleSchema, "./test_data/simple-schema-v2.json"},
+			wantCode:    exitOK,
+		},
+		{
+			description: "should report a breaking change between JSON schemas",
+			args:        []string{"diff", simpleSchema, fmt.Sprintf("%s/mutual-person.json", schemaTestDir)},
+			wantCode:    exitChanges,
+		},
+		{
+			description: "should check schemas referring to each other against a committed schema",
+			args: []string{
+				"check", "-graphql", "./test_data/mutual.graphql",
+				fmt.Sprintf("%s/mutual-person.json", schemaTestDir), fmt.Sprintf("%s/mutual-company.json", schemaTestDir),
+			},
+			wantCode: exitOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			if code := run(test.args); code != test.wantCode {
+				t.Errorf("did not get the expected exit code.\nwant - %d\ngot - %d", test.wantCode, code)
+			}
+		})
+	}
+}
+
+func TestRunConvert(t *testing.T) {
+	schemaTestDir := "./graphql/test_data/jsonschema"
+	output := filepath.Join(t.TempDir(), "schema.graphql")
+
+	code := run([]string{
+		"convert", "-o", output,
+		fmt.Sprintf("%s/mutual-person.json", schemaTestDir), fmt.Sprintf("%s/mutual-company.json", schemaTestDir),
+	})
+	if code != exitOK {
+		t.Fatalf("did not get the expected exit code.\nwant - %d\ngot - %d", exitOK, code)
+	}
+
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("error reading generated graphql schema at path %q: %v", output, err)
+	}
+
+	want, err := os.ReadFile("./test_data/mutual.graphql")
+	if err != nil {
+		t.Fatalf("error reading test graphql schema file: %v", err)
+	}
+
+	if string(want) != string(got) {
+		t.Errorf("did not get expected generated result.\nwant - %s\ngot - %s", want, got)
+	}
+}
+
+func TestRunReverse(t *testing.T) {
+	input := "./test_data/event.graphql"
+	dir := t.TempDir()
+	reversed := filepath.Join(dir, "event.json")
+	output := filepath.Join(dir, "event.graphql")
+
+	if code := run([]string{"reverse", "-root", "Event", "-o", reversed, input}); code != exitOK {
+		t.Fatalf("did not get the expected exit code when reversing.\nwant - %d\ngot - %d", exitOK, code)
+	}
+
+	if code := run([]string{"convert", "-o", output, reversed}); code != exitOK {
+		t.Fatalf("did not get the expected exit code when converting.\nwant - %d\ngot - %d", exitOK, code)
+	}
+
+	got, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("error reading generated graphql schema at path %q: %v", output, err)
+	}
+
+	want, err := os.ReadFile(input)
+	if err != nil {
+		t.Fatalf("error reading test graphql schema file: %v", err)
+	}
+
+	if string(want) != string(got) {
+		t.Errorf("did not get the schema back after a round trip.\nwant - %s\ngot - %s", want, got)
+	}
+}
@@ -0,0 +1,513 @@
+// Package diff finds the changes between two versions of a GraphQL schema, and classifies how they affect clients.
+package diff
+
+import (
+	"fmt"
+	"jgschema/graphql"
+	"jgschema/sdl"
+	"reflect"
+	"sort"
+)
+
+// Severity classifies how a change affects existing clients of a schema.
+type Severity string
+
+const (
+	// Breaking changes make valid operations of existing clients fail, e.g. a removed field.
+	Breaking Severity = "BREAKING"
+	// Dangerous changes keep existing operations valid, but can change how clients behave, e.g. a new enum value
+	// clients don't handle.
+	Dangerous Severity = "DANGEROUS"
+	// Safe changes don't affect existing clients, e.g. a new field.
+	Safe Severity = "SAFE"
+)
+
+// severityOrder sorts changes from the most to the least severe.
+var severityOrder = map[Severity]int{Breaking: 0, Dangerous: 1, Safe: 2}
+
+// ChangeKind identifies what changed.
+type ChangeKind string
+
+const (
+	TypeAdded                   ChangeKind = "TYPE_ADDED"
+	TypeRemoved                 ChangeKind = "TYPE_REMOVED"
+	TypeKindChanged             ChangeKind = "TYPE_KIND_CHANGED"
+	TypeDescriptionChanged      ChangeKind = "TYPE_DESCRIPTION_CHANGED"
+	FieldAdded                  ChangeKind = "FIELD_ADDED"
+	FieldRemoved                ChangeKind = "FIELD_REMOVED"
+	FieldTypeChanged            ChangeKind = "FIELD_TYPE_CHANGED"
+	FieldDescriptionChanged     ChangeKind = "FIELD_DESCRIPTION_CHANGED"
+	FieldDeprecated             ChangeKind = "FIELD_DEPRECATED"
+	FieldDeprecationRemoved     ChangeKind = "FIELD_DEPRECATION_REMOVED"
+	FieldDefaultChanged         ChangeKind = "FIELD_DEFAULT_CHANGED"
+	FieldConstraintsChanged     ChangeKind = "FIELD_CONSTRAINTS_CHANGED"
+	EnumValueAdded              ChangeKind = "ENUM_VALUE_ADDED"
+	EnumValueRemoved            ChangeKind = "ENUM_VALUE_REMOVED"
+	EnumValueDeprecated         ChangeKind = "ENUM_VALUE_DEPRECATED"
+	EnumValueDeprecationRemoved ChangeKind = "ENUM_VALUE_DEPRECATION_REMOVED"
+	UnionMemberAdded            ChangeKind = "UNION_MEMBER_ADDED"
+	UnionMemberRemoved          ChangeKind = "UNION_MEMBER_REMOVED"
+	InterfaceAdded              ChangeKind = "INTERFACE_ADDED"
+	InterfaceRemoved            ChangeKind = "INTERFACE_REMOVED"
+)
+
+// Change is a single difference between two versions of a schema.
+type Change struct {
+	Kind     ChangeKind `json:"kind"`
+	Severity Severity   `json:"severity"`
+	// Path locates the change: a type name, followed by a field, enum value or member name when the change is about
+	// one of those, e.g. "Order.total".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// kindNames names the kinds of schemas in messages.
+var kindNames = map[graphql.SchemaKind]string{
+	graphql.KindObject:    "object type",
+	graphql.KindEnum:      "enum",
+	graphql.KindUnion:     "union",
+	graphql.KindInput:     "input type",
+	graphql.KindScalar:    "scalar",
+	graphql.KindInterface: "interface",
+}
+
+// CompareFiles compares the GraphQL schemas at oldPath and newPath, see Compare.
+func CompareFiles(oldPath, newPath string) ([]Change, error) {
+	oldSchemas, err := sdl.ParseFile(oldPath)
+	if err != nil {
+		return nil, err
+	}
+
+	newSchemas, err := sdl.ParseFile(newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return Compare(oldSchemas, newSchemas)
+}
+
+// Compare returns the changes going from the oldSchemas to the newSchemas version of a schema, sorted from the most to
+// the least severe. Both can come from graphql.Transform or sdl.Parse.
+func Compare(oldSchemas, newSchemas []graphql.Schema) ([]Change, error) {
+	oldTypes, err := types(oldSchemas)
+	if err != nil {
+		return nil, fmt.Errorf("error reading old schemas: %w", err)
+	}
+
+	newTypes, err := types(newSchemas)
+	if err != nil {
+		return nil, fmt.Errorf("error reading new schemas: %w", err)
+	}
+
+	var changes []Change
+	for _, name := range oldTypes.names {
+		oldSchema := oldTypes.schemas[name]
+		newSchema, ok := newTypes.schemas[name]
+		if !ok {
+			changes = append(changes, Change{
+				Kind:     TypeRemoved,
+				Severity: Breaking,
+				Path:     name,
+				Message:  fmt.Sprintf("%s %q was removed", kindNames[oldSchema.Kind], name),
+			})
+			continue
+		}
+
+		typeChanges, err := compareTypes(oldSchema, newSchema)
+		if err != nil {
+			return nil, fmt.Errorf("error comparing type %q: %w", name, err)
+		}
+		changes = append(changes, typeChanges...)
+	}
+
+	for _, name := range newTypes.names {
+		if _, ok := oldTypes.schemas[name]; !ok {
+			changes = append(changes, Change{
+				Kind:     TypeAdded,
+				Severity: Safe,
+				Path:     name,
+				Message:  fmt.Sprintf("%s %q was added", kindNames[newTypes.schemas[name].Kind], name),
+			})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return severityOrder[changes[i].Severity] < severityOrder[changes[j].Severity]
+	})
+
+	return changes, nil
+}
+
+// typeSet holds schemas by type name, along with the names in the order they were defined in.
+type typeSet struct {
+	names   []string
+	schemas map[string]graphql.Schema
+}
+
+// types indexes schemas by name. They are first generated and parsed back, which names types and built-in scalars
+// the same way whether the schemas were transformed from JSON schemas or parsed from SDL.
+func types(schemas []graphql.Schema) (typeSet, error) {
+	source, err := graphql.Generate(schemas)
+	if err != nil {
+		return typeSet{}, err
+	}
+
+	parsed, err := sdl.Parse(source)
+	if err != nil {
+		return typeSet{}, err
+	}
+
+	set := typeSet{schemas: map[string]graphql.Schema{}}
+	for _, schema := range parsed {
+		set.names = append(set.names, schema.TypeName)
+		set.schemas[schema.TypeName] = schema
+	}
+
+	return set, nil
+}
+
+// compareTypes returns the changes between two versions of the type with the same name.
+func compareTypes(oldSchema, newSchema graphql.Schema) ([]Change, error) {
+	name := newSchema.TypeName
+
+	if oldSchema.Kind != newSchema.Kind {
+		return []Change{{
+			Kind:     TypeKindChanged,
+			Severity: Breaking,
+			Path:     name,
+			Message:  fmt.Sprintf("%q changed from %s to %s", name, kindNames[oldSchema.Kind], kindNames[newSchema.Kind]),
+		}}, nil
+	}
+
+	var changes []Change
+	if oldSchema.Description != newSchema.Description {
+		changes = append(changes, Change{
+			Kind:     TypeDescriptionChanged,
+			Severity: Safe,
+			Path:     name,
+			Message:  fmt.Sprintf("description of %s %q changed", kindNames[newSchema.Kind], name),
+		})
+	}
+
+	switch newSchema.Kind {
+	case graphql.KindEnum:
+		changes = append(changes, compareEnums(oldSchema, newSchema)...)
+	case graphql.KindUnion:
+		changes = append(changes, compareMembers(name, UnionMemberRemoved, UnionMemberAdded, "member", oldSchema.Types, newSchema.Types)...)
+	case graphql.KindObject, graphql.KindInterface, graphql.KindInput:
+		changes = append(changes, compareMembers(name, InterfaceRemoved, InterfaceAdded, "interface", oldSchema.Interfaces, newSchema.Interfaces)...)
+
+		fieldChanges, err := compareFields(oldSchema, newSchema)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, fieldChanges...)
+	}
+
+	return changes, nil
+}
+
+// compareFields returns the changes between the fields of two versions of an object, interface or input type.
+// Output fields can safely become non-null, while input fields can safely become nullable, but not the other way
+// around.
+func compareFields(oldSchema, newSchema graphql.Schema) ([]Change, error) {
+	name := newSchema.TypeName
+	input := newSchema.Kind == graphql.KindInput
+
+	newFields := map[string]graphql.Field{}
+	for _, field := range newSchema.Fields {
+		newFields[field.Name] = field
+	}
+
+	var changes []Change
+	oldFields := map[string]bool{}
+	for _, oldField := range oldSchema.Fields {
+		oldFields[oldField.Name] = true
+		path := name + "." + oldField.Name
+
+		newField, ok := newFields[oldField.Name]
+		if !ok {
+			changes = append(changes, Change{
+				Kind:     FieldRemoved,
+				Severity: Breaking,
+				Path:     path,
+				Message:  fmt.Sprintf("field %q was removed from %s %q", oldField.Name, kindNames[newSchema.Kind], name),
+			})
+			continue
+		}
+
+		oldType, err := oldField.TypeRef()
+		if err != nil {
+			return nil, err
+		}
+
+		newType, err := newField.TypeRef()
+		if err != nil {
+			return nil, err
+		}
+
+		if oldType != newType {
+			severity := Breaking
+			if input && isSafeInputChange(oldField, newField) || !input && isSafeOutputChange(oldField, newField) {
+				severity = Safe
+			}
+
+			changes = append(changes, Change{
+				Kind:     FieldTypeChanged,
+				Severity: severity,
+				Path:     path,
+				Message:  fmt.Sprintf("field %q changed type from %s to %s", path, oldType, newType),
+			})
+		}
+
+		if oldField.Description != newField.Description {
+			changes = append(changes, Change{
+				Kind:     FieldDescriptionChanged,
+				Severity: Safe,
+				Path:     path,
+				Message:  fmt.Sprintf("description of field %q changed", path),
+			})
+		}
+
+		if !oldField.Deprecated && newField.Deprecated {
+			changes = append(changes, Change{
+				Kind:     FieldDeprecated,
+				Severity: Safe,
+				Path:     path,
+				Message:  fmt.Sprintf("field %q was deprecated", path),
+			})
+		} else if oldField.Deprecated && !newField.Deprecated {
+			changes = append(changes, Change{
+				Kind:     FieldDeprecationRemoved,
+				Severity: Safe,
+				Path:     path,
+				Message:  fmt.Sprintf("field %q is no longer deprecated", path),
+			})
+		}
+
+		if oldField.Default != newField.Default {
+			changes = append(changes, Change{
+				Kind:     FieldDefaultChanged,
+				Severity: Dangerous,
+				Path:     path,
+				Message:  fmt.Sprintf("default value of field %q changed from %s to %s", path, literal(oldField.Default), literal(newField.Default)),
+			})
+		}
+
+		if !reflect.DeepEqual(oldField.Constraints, newField.Constraints) {
+			changes = append(changes, Change{
+				Kind:     FieldConstraintsChanged,
+				Severity: Dangerous,
+				Path:     path,
+				Message:  fmt.Sprintf("constraints of field %q changed", path),
+			})
+		}
+	}
+
+	for _, newField := range newSchema.Fields {
+		if oldFields[newField.Name] {
+			continue
+		}
+
+		path := name + "." + newField.Name
+		change := Change{
+			Kind:     FieldAdded,
+			Severity: Safe,
+			Path:     path,
+			Message:  fmt.Sprintf("field %q was added to %s %q", newField.Name, kindNames[newSchema.Kind], name),
+		}
+
+		// Clients already sending the input don't set the new field.
+		if input && newField.Required && newField.Default == "" {
+			change.Severity = Breaking
+			change.Message = fmt.Sprintf("required field %q was added to input type %q", newField.Name, name)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// compareEnums returns the changes between the values of two versions of an enum. New values are dangerous, since
+// clients may not handle them.
+func compareEnums(oldSchema, newSchema graphql.Schema) []Change {
+	name := newSchema.TypeName
+
+	newValues := map[string]graphql.EnumValue{}
+	for _, value := range newSchema.Values {
+		newValues[value.Name] = value
+	}
+
+	var changes []Change
+	oldValues := map[string]bool{}
+	for _, oldValue := range oldSchema.Values {
+		oldValues[oldValue.Name] = true
+		path := name + "." + oldValue.Name
+
+		newValue, ok := newValues[oldValue.Name]
+		switch {
+		case !ok:
+			changes = append(changes, Change{
+				Kind:     EnumValueRemoved,
+				Severity: Breaking,
+				Path:     path,
+				Message:  fmt.Sprintf("value %q was removed from enum %q", oldValue.Name, name),
+			})
+		case !oldValue.Deprecated && newValue.Deprecated:
+			changes = append(changes, Change{
+				Kind:     EnumValueDeprecated,
+				Severity: Safe,
+				Path:     path,
+				Message:  fmt.Sprintf("value %q of enum %q was deprecated", oldValue.Name, name),
+			})
+		case oldValue.Deprecated && !newValue.Deprecated:
+			changes = append(changes, Change{
+				Kind:     EnumValueDeprecationRemoved,
+				Severity: Safe,
+				Path:     path,
+				Message:  fmt.Sprintf("value %q of enum %q is no longer deprecated", oldValue.Name, name),
+			})
+		}
+	}
+
+	for _, newValue := range newSchema.Values {
+		if !oldValues[newValue.Name] {
+			changes = append(changes, Change{
+				Kind:     EnumValueAdded,
+				Severity: Dangerous,
+				Path:     name + "." + newValue.Name,
+				Message:  fmt.Sprintf("value %q was added to enum %q", newValue.Name, name),
+			})
+		}
+	}
+
+	return changes
+}
+
+// compareMembers returns the changes between two versions of a list of type names, such as the members of a union or
+// the interfaces of an object type. Removing one is breaking, while adding one is dangerous since clients may not
+// handle the new type.
+func compareMembers(name string, removed, added ChangeKind, noun string, oldNames, newNames []string) []Change {
+	var changes []Change
+	for _, oldName := range oldNames {
+		if !contains(oldName, newNames) {
+			changes = append(changes, Change{
+				Kind:     removed,
+				Severity: Breaking,
+				Path:     name + "." + oldName,
+				Message:  fmt.Sprintf("%s %q was removed from %q", noun, oldName, name),
+			})
+		}
+	}
+
+	for _, newName := range newNames {
+		if !contains(newName, oldNames) {
+			changes = append(changes, Change{
+				Kind:     added,
+				Severity: Dangerous,
+				Path:     name + "." + newName,
+				Message:  fmt.Sprintf("%s %q was added to %q", noun, newName, name),
+			})
+		}
+	}
+
+	return changes
+}
+
+// nonNull returns whether the field and the items of each of its lists are non-null, from the outermost type inwards.
+func nonNull(field graphql.Field) []bool {
+	levels := []bool{field.Required}
+	if !field.Array {
+		return levels
+	}
+
+	depth := field.ListDepth
+	if depth == 0 {
+		depth = 1
+	}
+
+	for level := 0; level < depth; level++ {
+		levels = append(levels, level < len(field.ItemsRequired) && field.ItemsRequired[level])
+	}
+
+	return levels
+}
+
+// isSafeOutputChange reports whether an output field changing from oldField to newField only made some of its types
+// non-null, which clients always handle.
+func isSafeOutputChange(oldField, newField graphql.Field) bool {
+	return sameWrappers(oldField, newField, func(oldNonNull, newNonNull bool) bool { return !oldNonNull || newNonNull })
+}
+
+// isSafeInputChange reports whether an input field changing from oldField to newField only made some of its types
+// nullable, which accepts every value clients already send.
+func isSafeInputChange(oldField, newField graphql.Field) bool {
+	return sameWrappers(oldField, newField, func(oldNonNull, newNonNull bool) bool { return oldNonNull || !newNonNull })
+}
+
+// sameWrappers reports whether both fields are of the same named type in as many lists, and allowed reports true for
+// the nullability of every level.
+func sameWrappers(oldField, newField graphql.Field, allowed func(oldNonNull, newNonNull bool) bool) bool {
+	if oldField.Type != newField.Type {
+		return false
+	}
+
+	oldLevels, newLevels := nonNull(oldField), nonNull(newField)
+	if len(oldLevels) != len(newLevels) {
+		return false
+	}
+
+	for i := range oldLevels {
+		if !allowed(oldLevels[i], newLevels[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// literal returns a default value for messages, which is "none" when there isn't any.
+func literal(value string) string {
+	if value == "" {
+		return "none"
+	}
+
+	return value
+}
+
+func contains(s string, ss []string) bool {
+	for _, item := range ss {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Report sums up the changes found by Compare, and is meant to be marshaled into JSON for tools such as PR bots.
+type Report struct {
+	Breaking  int      `json:"breaking"`
+	Dangerous int      `json:"dangerous"`
+	Safe      int      `json:"safe"`
+	Changes   []Change `json:"changes"`
+}
+
+// NewReport counts the changes of every severity.
+func NewReport(changes []Change) Report {
+	report := Report{Changes: []Change{}}
+	for _, change := range changes {
+		switch change.Severity {
+		case Breaking:
+			report.Breaking++
+		case Dangerous:
+			report.Dangerous++
+		case Safe:
+			report.Safe++
+		}
+		report.Changes = append(report.Changes, change)
+	}
+
+	return report
+}
@@ -0,0 +1,239 @@
+package diff
+
+import (
+	"fmt"
+	"jgschema/graphql"
+	"jgschema/sdl"
+	"reflect"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	type test struct {
+		description string
+		old         string
+		new         string
+		want        []string
+	}
+
+	tests := []test{
+		{
+			description: "should find no changes between the same schemas",
+			old:         `type Order { id: ID!, total: Float }`,
+			new:         `type Order { total: Float, id: ID! }`,
+		},
+		{
+			description: "should report removed and added types and fields",
+			old:         `type Order { id: ID!, total: Float } type Customer { name: String }`,
+			new:         `type Order { id: ID!, createdAt: String } type Invoice { id: ID! }`,
+			want: []string{
+				"BREAKING FIELD_REMOVED Order.total",
+				"BREAKING TYPE_REMOVED Customer",
+				"SAFE FIELD_ADDED Order.createdAt",
+				"SAFE TYPE_ADDED Invoice",
+			},
+		},
+		{
+			description: "should only allow output fields to become non-null",
+			old:         `type Order { id: ID, lines: [String], total: Float! }`,
+			new:         `type Order { id: ID!, lines: [String!]!, total: Float }`,
+			want: []string{
+				"BREAKING FIELD_TYPE_CHANGED Order.total",
+				"SAFE FIELD_TYPE_CHANGED Order.id",
+				"SAFE FIELD_TYPE_CHANGED Order.lines",
+			},
+		},
+		{
+			description: "should only allow input fields to become nullable",
+			old:         `input OrderFilter { status: String, page: Int! }`,
+			new:         `input OrderFilter { status: String!, page: Int }`,
+			want: []string{
+				"BREAKING FIELD_TYPE_CHANGED OrderFilter.status",
+				"SAFE FIELD_TYPE_CHANGED OrderFilter.page",
+			},
+		},
+		{
+			description: "should report changed types and list wrappers as breaking",
+			old:         `type Order { total: Float, lines: [String] }`,
+			new:         `type Order { total: Int, lines: String }`,
+			want: []string{
+				"BREAKING FIELD_TYPE_CHANGED Order.total",
+				"BREAKING FIELD_TYPE_CHANGED Order.lines",
+			},
+		},
+		{
+			description: "should only break on added input fields that are required without a default",
+			old:         `input OrderFilter { status: String }`,
+			new:         `input OrderFilter { status: String, page: Int!, size: Int! = 10, sort: String }`,
+			want: []string{
+				"BREAKING FIELD_ADDED OrderFilter.page",
+				"SAFE FIELD_ADDED OrderFilter.size",
+				"SAFE FIELD_ADDED OrderFilter.sort",
+			},
+		},
+		{
+			description: "should report changed defaults as dangerous",
+			old:         `input OrderFilter { size: Int = 10 }`,
+			new:         `input OrderFilter { size: Int = 20 }`,
+			want:        []string{"DANGEROUS FIELD_DEFAULT_CHANGED OrderFilter.size"},
+		},
+		{
+			description: "should report removed enum values as breaking and added ones as dangerous",
+			old:         `enum Status { OPEN CLOSED }`,
+			new:         `enum Status { OPEN PENDING }`,
+			want: []string{
+				"BREAKING ENUM_VALUE_REMOVED Status.CLOSED",
+				"DANGEROUS ENUM_VALUE_ADDED Status.PENDING",
+			},
+		},
+		{
+			description: "should report deprecations as safe",
+			old:         `type Order { id: ID, total: Float } enum Status { OPEN, CLOSED }`,
+			new: `type Order { id: ID @deprecated(reason: "Use key."), total: Float }
+				enum Status { OPEN, CLOSED @deprecated }`,
+			want: []string{
+				"SAFE FIELD_DEPRECATED Order.id",
+				"SAFE ENUM_VALUE_DEPRECATED Status.CLOSED",
+			},
+		},
+		{
+			description: "should report changed union members and interfaces",
+			old: `interface Node { id: ID } type Dog implements Node { id: ID } type Cat { id: ID }
+				union Pet = Dog | Cat`,
+			new: `interface Node { id: ID } type Dog { id: ID } type Cat implements Node { id: ID }
+				union Pet = Dog`,
+			want: []string{
+				"BREAKING INTERFACE_REMOVED Dog.Node",
+				"BREAKING UNION_MEMBER_REMOVED Pet.Cat",
+				"DANGEROUS INTERFACE_ADDED Cat.Node",
+			},
+		},
+		{
+			description: "should report types changing kind as breaking",
+			old:         `type Status { name: String }`,
+			new:         `enum Status { OPEN }`,
+			want:        []string{"BREAKING TYPE_KIND_CHANGED Status"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			oldSchemas, err := sdl.Parse(test.old)
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			newSchemas, err := sdl.Parse(test.new)
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			changes, err := Compare(oldSchemas, newSchemas)
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			if got := summarize(changes); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("did not get expected result.\nwant - %+v\ngot - %+v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestCompareTransformed(t *testing.T) {
+	// Schemas transformed from JSON schemas are compared with the same names as parsed ones.
+	oldSchemas := []graphql.Schema{
+		{
+			TypeName: "order",
+			Fields: []graphql.Field{
+				{Name: "id", Type: "ID", Required: true},
+				{Name: "total", Type: "number"},
+			},
+		},
+	}
+
+	newSchemas, err := sdl.Parse(`type Order { id: ID!, total: Float! }`)
+	if err != nil {
+		t.Fatalf("got the following error when one wasn't expected: %v", err)
+	}
+
+	changes, err := Compare(oldSchemas, newSchemas)
+	if err != nil {
+		t.Fatalf("got the following error when one wasn't expected: %v", err)
+	}
+
+	want := []Change{
+		{
+			Kind:     FieldTypeChanged,
+			Severity: Safe,
+			Path:     "Order.total",
+			Message:  `field "Order.total" changed type from Float to Float!`,
+		},
+	}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("did not get expected result.\nwant - %+v\ngot - %+v", want, changes)
+	}
+}
+
+func TestCompareFiles(t *testing.T) {
+	type test struct {
+		description string
+		oldPath     string
+		newPath     string
+		want        Report
+		wantErr     error
+	}
+
+	tests := []test{
+		{
+			description: "should report the changes between two files",
+			oldPath:     "test_data/old.graphql",
+			newPath:     "test_data/new.graphql",
+			want:        Report{Breaking: 6, Dangerous: 2, Safe: 2},
+		},
+		{
+			description: "should report no changes for the same file",
+			oldPath:     "test_data/old.graphql",
+			newPath:     "test_data/old.graphql",
+			want:        Report{},
+		},
+		{
+			description: "should fail on missing files",
+			oldPath:     "test_data/old.graphql",
+			newPath:     "test_data/missing.graphql",
+			wantErr:     fmt.Errorf("error reading graphql schema: open test_data/missing.graphql: no such file or directory"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			changes, err := CompareFiles(test.oldPath, test.newPath)
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			got := NewReport(changes)
+			if got.Breaking != test.want.Breaking || got.Dangerous != test.want.Dangerous || got.Safe != test.want.Safe {
+				t.Errorf("did not get expected result.\nwant - %+v\ngot - %+v", test.want, got)
+			}
+			if len(got.Changes) != len(changes) {
+				t.Errorf("expected the report to hold %d changes, got %d", len(changes), len(got.Changes))
+			}
+		})
+	}
+}
+
+// summarize describes every change by its severity, kind and path.
+func summarize(changes []Change) []string {
+	var summaries []string
+	for _, change := range changes {
+		summaries = append(summaries, fmt.Sprintf("%s %s %s", change.Severity, change.Kind, change.Path))
+	}
+
+	return summaries
+}
@@ -0,0 +1,243 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+)
+
+// contextLines is the number of unchanged lines shown around every change of a diff.
+const contextLines = 3
+
+// edit is a single line of a diff: kept (' '), removed ('-') or added ('+').
+type edit struct {
+	op   byte
+	line string
+}
+
+// unifiedDiff returns the unified diff going from the lines of a to the lines of b, labelled with the given names.
+// It is empty when both are the same.
+func unifiedDiff(a, b []string, aName, bName string) string {
+	edits := diffLines(a, b)
+
+	changed := false
+	for _, e := range edits {
+		if e.op != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", aName, bName))
+
+	// aLines and bLines hold the line number, in a and b, of the edit at the same index.
+	aLines := make([]int, len(edits)+1)
+	bLines := make([]int, len(edits)+1)
+	aLine, bLine := 1, 1
+	for i, e := range edits {
+		aLines[i], bLines[i] = aLine, bLine
+		if e.op != '+' {
+			aLine++
+		}
+		if e.op != '-' {
+			bLine++
+		}
+	}
+	aLines[len(edits)], bLines[len(edits)] = aLine, bLine
+
+	for start := 0; start < len(edits); {
+		if edits[start].op == ' ' {
+			start++
+			continue
+		}
+
+		// A hunk spans from the context before its first change to the context after its last change, merging
+		// changes separated by no more than twice the context.
+		first := start - contextLines
+		if first < 0 {
+			first = 0
+		}
+
+		end := start
+		for i := start; i < len(edits); i++ {
+			if edits[i].op != ' ' {
+				end = i + 1
+			} else if i-end >= 2*contextLines {
+				break
+			}
+		}
+
+		last := end + contextLines
+		if last > len(edits) {
+			last = len(edits)
+		}
+
+		aCount, bCount := 0, 0
+		for _, e := range edits[first:last] {
+			if e.op != '+' {
+				aCount++
+			}
+			if e.op != '-' {
+				bCount++
+			}
+		}
+
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", aLines[first], aCount, bLines[first], bCount))
+		for _, e := range edits[first:last] {
+			sb.WriteString(fmt.Sprintf("%c%s\n", e.op, e.line))
+		}
+
+		start = last
+	}
+
+	return sb.String()
+}
+
+// diffLines returns the edits turning a into b, keeping the longest common subsequence of lines. Schemas mostly drift
+// by a few lines, so the lines a and b start and end with are kept as they are, leaving myersEdits to the lines in
+// between.
+func diffLines(a, b []string) []edit {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]edit, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		edits = append(edits, edit{op: ' ', line: line})
+	}
+	edits = append(edits, myersEdits(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, edit{op: ' ', line: line})
+	}
+
+	slideDown(edits)
+	return edits
+}
+
+// slideDown moves every run of added or removed lines past the unchanged lines following it that read the same as
+// its first line. Trimming the common suffix otherwise keeps a line such as a closing brace at the end, splitting the
+// type added before it around the brace of the previous type.
+func slideDown(edits []edit) {
+	for start := 0; start < len(edits); start++ {
+		if edits[start].op == ' ' {
+			continue
+		}
+
+		end := start
+		for end < len(edits) && edits[end].op == edits[start].op {
+			end++
+		}
+
+		// Swapping the first line of the run with the matching line after it shifts the whole run down by one.
+		for end < len(edits) && edits[end].op == ' ' && edits[end].line == edits[start].line {
+			edits[start], edits[end] = edits[end], edits[start]
+			start++
+			end++
+		}
+		start = end - 1
+	}
+}
+
+// maxEditDistance is the number of added and removed lines past which myersEdits stops looking for the shortest
+// edits. The paths it keeps track of take memory quadratic in that number.
+const maxEditDistance = 1000
+
+// myersEdits returns the shortest edits turning a into b, found with Myers' O(ND) algorithm, D being the number of
+// added and removed lines. Past maxEditDistance, every line of a is removed and every line of b added instead.
+func myersEdits(a, b []string) []edit {
+	limit := len(a) + len(b)
+	if limit > maxEditDistance {
+		limit = maxEditDistance
+	}
+
+	// v[offset+k] is the furthest x reached on diagonal k, where x-y = k, by a path of d edits. trace[d] holds the
+	// diagonals -d to d of v as they were before looking for paths of d edits.
+	offset := limit + 1
+	v := make([]int, 2*limit+3)
+	var trace [][]int
+	for d := 0; d <= limit; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+
+		for k := -d; k <= d; k += 2 {
+			// Extend the furthest path of the neighbouring diagonals, down by adding a line of b, or right by removing
+			// a line of a.
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+
+			y := x - k
+			for x < len(a) && y < len(b) && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= len(a) && y >= len(b) {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+
+	edits := make([]edit, 0, len(a)+len(b))
+	for _, line := range a {
+		edits = append(edits, edit{op: '-', line: line})
+	}
+	for _, line := range b {
+		edits = append(edits, edit{op: '+', line: line})
+	}
+
+	return edits
+}
+
+// backtrack walks the paths recorded by myersEdits back from the end of a and b, returning the edits of the shortest
+// one.
+func backtrack(trace [][]int, a, b []string) []edit {
+	edits := make([]edit, 0, len(a)+len(b))
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		previous := trace[d]
+		k := x - y
+
+		previousK := k - 1
+		if k == -d || (k != d && previous[k-1+d] < previous[k+1+d]) {
+			previousK = k + 1
+		}
+		previousX := previous[previousK+d]
+		previousY := previousX - previousK
+
+		for x > previousX && y > previousY {
+			edits = append(edits, edit{op: ' ', line: a[x-1]})
+			x--
+			y--
+		}
+
+		if x == previousX {
+			edits = append(edits, edit{op: '+', line: b[y-1]})
+			y--
+		} else {
+			edits = append(edits, edit{op: '-', line: a[x-1]})
+			x--
+		}
+	}
+	for ; x > 0; x-- {
+		edits = append(edits, edit{op: ' ', line: a[x-1]})
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+
+	return edits
+}
@@ -0,0 +1,88 @@
+package drift
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	type test struct {
+		description string
+		a           string
+		b           string
+		want        string
+	}
+
+	// Long enough that a table of every pair of lines wouldn't fit in memory.
+	lines := strings.Repeat("line\n", 100000)
+
+	// Different enough that looking for the shortest edits would take too much memory.
+	var removed, added, replaced []string
+	for i := 0; i < maxEditDistance; i++ {
+		removed = append(removed, fmt.Sprintf("a%d", i))
+		added = append(added, fmt.Sprintf("b%d", i))
+		replaced = append(replaced, fmt.Sprintf("-a%d\n", i))
+	}
+	for i := 0; i < maxEditDistance; i++ {
+		replaced = append(replaced, fmt.Sprintf("+b%d\n", i))
+	}
+
+	tests := []test{
+		{
+			description: "should be empty for the same lines",
+			a:           "a\nb\nc",
+			b:           "a\nb\nc",
+		},
+		{
+			description: "should split changes far apart into several hunks",
+			a:           "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12",
+			b:           "0\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13",
+			want: "--- a\n+++ b\n" +
+				"@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n" +
+				"@@ -10,3 +10,4 @@\n 10\n 11\n 12\n+13\n",
+		},
+		{
+			description: "should merge changes close to each other into a single hunk",
+			a:           "1\n2\n3\n4\n5\n6",
+			b:           "1\nx\n3\n4\ny\n6",
+			want:        "--- a\n+++ b\n@@ -1,6 +1,6 @@\n 1\n-2\n+x\n 3\n 4\n-5\n+y\n 6\n",
+		},
+		{
+			description: "should only compare the lines between the ones long inputs start and end with",
+			a:           lines + "old\n" + lines + "end",
+			b:           lines + "new\n" + lines + "end",
+			want:        "--- a\n+++ b\n@@ -99998,7 +99998,7 @@\n line\n line\n line\n-old\n+new\n line\n line\n line\n",
+		},
+		{
+			description: "should diff long inputs with changes far apart",
+			a:           "old\n" + lines + "old",
+			b:           "new\n" + lines + "new",
+			want: "--- a\n+++ b\n" +
+				"@@ -1,4 +1,4 @@\n-old\n+new\n line\n line\n line\n" +
+				"@@ -99999,4 +99999,4 @@\n line\n line\n line\n-old\n+new\n",
+		},
+		{
+			description: "should replace every line when the inputs have too many differences",
+			a:           strings.Join(removed, "\n"),
+			b:           strings.Join(added, "\n"),
+			want: fmt.Sprintf("--- a\n+++ b\n@@ -1,%d +1,%d @@\n", maxEditDistance, maxEditDistance) +
+				strings.Join(replaced, ""),
+		},
+		{
+			description: "should keep a type added after another one in a single block",
+			a:           "x\ntype A {\n}",
+			b:           "y\ntype A {\n}\n\ntype B {\n}",
+			want:        "--- a\n+++ b\n@@ -1,3 +1,6 @@\n-x\n+y\n type A {\n }\n+\n+type B {\n+}\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got := unifiedDiff(strings.Split(test.a, "\n"), strings.Split(test.b, "\n"), "a", "b")
+			if got != test.want {
+				t.Errorf("did not get expected diff.\nwant - %q\ngot - %q", test.want, got)
+			}
+		})
+	}
+}
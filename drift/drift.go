@@ -0,0 +1,116 @@
+// Package drift detects when a committed GraphQL schema no longer matches the one generated from its JSON schemas.
+package drift
+
+import (
+	"fmt"
+	"jgschema/graphql"
+	"jgschema/sdl"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Options configures which differences between the committed and the generated GraphQL schemas are ignored.
+// Layout, such as indentation, blank lines and how descriptions are quoted, is always ignored. The zero value also
+// ignores the order of definitions and whitespace within descriptions.
+type Options struct {
+	// StrictOrder makes the order of types, fields, enum values, union members and interfaces significant.
+	StrictOrder bool
+	// StrictWhitespace compares descriptions exactly. By default, any run of whitespace in a description is treated as
+	// a single space, so that rewrapped descriptions still match.
+	StrictWhitespace bool
+	// IgnoreDescriptions leaves descriptions out of the comparison.
+	IgnoreDescriptions bool
+}
+
+// CheckFile compares generated with the committed GraphQL schema at path, see Check.
+func CheckFile(generated []graphql.Schema, path string, opts Options) (string, error) {
+	committed, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("error reading graphql schema: %w", err)
+	}
+
+	diff, err := check(generated, string(committed), path, opts)
+	if err != nil {
+		return "", fmt.Errorf("error checking %q: %w", path, err)
+	}
+
+	return diff, nil
+}
+
+// Check compares the schemas generated from JSON schemas with the source of the committed GraphQL schema. Both are
+// read back into schemas and written again in the same layout, so only meaningful differences remain. It returns a
+// unified diff going from the committed schema to the generated one, which is empty when they match.
+func Check(generated []graphql.Schema, committed string, opts Options) (string, error) {
+	return check(generated, committed, "committed", opts)
+}
+
+func check(generated []graphql.Schema, committed, committedName string, opts Options) (string, error) {
+	committedSchemas, err := sdl.Parse(committed)
+	if err != nil {
+		return "", fmt.Errorf("error parsing committed graphql schema: %w", err)
+	}
+
+	// The generated schemas go through the same parser, which names types and built-in scalars the same way.
+	source, err := graphql.Generate(generated)
+	if err != nil {
+		return "", err
+	}
+
+	generatedSchemas, err := sdl.Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("error parsing generated graphql schema: %w", err)
+	}
+
+	want, err := graphql.Generate(normalize(committedSchemas, opts))
+	if err != nil {
+		return "", fmt.Errorf("error writing committed graphql schema: %w", err)
+	}
+
+	got, err := graphql.Generate(normalize(generatedSchemas, opts))
+	if err != nil {
+		return "", fmt.Errorf("error writing generated graphql schema: %w", err)
+	}
+
+	return unifiedDiff(strings.Split(want, "\n"), strings.Split(got, "\n"), committedName, "generated"), nil
+}
+
+// normalize strips the differences opts ignores from schemas.
+func normalize(schemas []graphql.Schema, opts Options) []graphql.Schema {
+	for i := range schemas {
+		schema := &schemas[i]
+		schema.Description = normalizeDescription(schema.Description, opts)
+		for j := range schema.Fields {
+			schema.Fields[j].Description = normalizeDescription(schema.Fields[j].Description, opts)
+		}
+		for j := range schema.Values {
+			schema.Values[j].Description = normalizeDescription(schema.Values[j].Description, opts)
+		}
+
+		if opts.StrictOrder {
+			continue
+		}
+
+		sort.SliceStable(schema.Fields, func(a, b int) bool { return schema.Fields[a].Name < schema.Fields[b].Name })
+		sort.SliceStable(schema.Values, func(a, b int) bool { return schema.Values[a].Name < schema.Values[b].Name })
+		sort.Strings(schema.Types)
+		sort.Strings(schema.Interfaces)
+	}
+
+	if !opts.StrictOrder {
+		sort.SliceStable(schemas, func(a, b int) bool { return schemas[a].TypeName < schemas[b].TypeName })
+	}
+
+	return schemas
+}
+
+func normalizeDescription(description string, opts Options) string {
+	switch {
+	case opts.IgnoreDescriptions:
+		return ""
+	case opts.StrictWhitespace:
+		return description
+	default:
+		return strings.Join(strings.Fields(description), " ")
+	}
+}
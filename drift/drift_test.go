@@ -0,0 +1,182 @@
+package drift
+
+import (
+	"fmt"
+	"jgschema/graphql"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	type test struct {
+		description string
+		committed   string
+		opts        Options
+		wantDiff    string
+		wantErr     error
+	}
+
+	generated := []graphql.Schema{
+		{
+			TypeName:    "order",
+			Description: "An order placed by a customer.",
+			Fields: []graphql.Field{
+				{Name: "id", Type: "ID", Required: true},
+				{Name: "status", Type: "status"},
+				{Name: "lines", Type: "string", Array: true, ItemsRequired: []bool{true}},
+			},
+		},
+		{TypeName: "status", Kind: graphql.KindEnum, Values: []graphql.EnumValue{{Name: "OPEN"}, {Name: "CLOSED", Description: "No longer taking changes."}}},
+	}
+
+	tests := []test{
+		{
+			description: "should match a schema with a different layout",
+			committed: `
+				"An order placed by a customer."
+				type Order { id: ID!, status: Status, lines: [String!] }
+
+				enum Status { OPEN, "No longer taking changes." CLOSED }`,
+		},
+		{
+			description: "should match a schema in a different order and with rewrapped descriptions",
+			committed: `
+				enum Status {
+					"""
+					No longer
+					taking changes.
+					"""
+					CLOSED
+					OPEN
+				}
+
+				"""
+				An order placed
+				by a customer.
+				"""
+				type Order {
+					lines: [String!]
+					status: Status
+					id: ID!
+				}`,
+		},
+		{
+			description: "should not match a schema in a different order with StrictOrder",
+			committed: `
+				"An order placed by a customer."
+				type Order {
+					id: ID!
+					status: Status
+					lines: [String!]
+				}
+
+				enum Status {
+					"No longer taking changes."
+					CLOSED
+					OPEN
+				}`,
+			opts: Options{StrictOrder: true},
+			wantDiff: `--- committed
++++ generated
+@@ -6,7 +6,8 @@
+ }
+` + " \n" + ` enum Status {
++	OPEN
++
+ 	"No longer taking changes."
+ 	CLOSED
+-	OPEN
+ }
+`,
+		},
+		{
+			description: "should not match rewrapped descriptions with StrictWhitespace",
+			committed: `
+				"An order placed  by a customer."
+				type Order { id: ID!, status: Status, lines: [String!] }
+				enum Status { OPEN, "No longer taking changes." CLOSED }`,
+			opts: Options{StrictWhitespace: true},
+			wantDiff: `--- committed
++++ generated
+@@ -1,4 +1,4 @@
+-"An order placed  by a customer."
++"An order placed by a customer."
+ type Order {
+ 	id: ID!
+ 	lines: [String!]
+`,
+		},
+		{
+			description: "should match different descriptions with IgnoreDescriptions",
+			committed: `
+				"An old order description."
+				type Order { "The id." id: ID!, status: Status, lines: [String!] }
+				enum Status { "Still taking changes." OPEN, "Closed." CLOSED }`,
+			opts: Options{IgnoreDescriptions: true},
+		},
+		{
+			description: "should not match different enum value descriptions",
+			committed: `
+				"An order placed by a customer."
+				type Order { id: ID!, status: Status, lines: [String!] }
+				enum Status { OPEN, "Closed." CLOSED }`,
+			wantDiff: `--- committed
++++ generated
+@@ -6,7 +6,7 @@
+ }
+` + " \n" + ` enum Status {
+-	"Closed."
++	"No longer taking changes."
+ 	CLOSED
+ 	OPEN
+ }
+`,
+		},
+		{
+			description: "should report changed types and missing definitions",
+			committed: `
+				"An order placed by a customer."
+				type Order { id: ID!, status: Status, lines: [String], total: Float }`,
+			wantDiff: `--- committed
++++ generated
+@@ -1,7 +1,12 @@
+ "An order placed by a customer."
+ type Order {
+ 	id: ID!
+-	lines: [String]
++	lines: [String!]
+ 	status: Status
+-	total: Float
+ }
++
++enum Status {
++	"No longer taking changes."
++	CLOSED
++	OPEN
++}
+`,
+		},
+		{
+			description: "should fail on invalid committed schemas",
+			committed:   "type Order {",
+			wantErr:     fmt.Errorf(`error parsing committed graphql schema: error on type "Order": line 1: unexpected end of document`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			diff, err := Check(generated, test.committed, test.opts)
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			if diff != test.wantDiff {
+				t.Errorf("did not get expected diff.\nwant - %s\ngot - %s", test.wantDiff, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Constraint is a single argument of the @constraint directive, made from a JSON Schema validation keyword.
+type Constraint struct {
+	// Name is the name of the validation keyword, e.g. "minLength".
+	Name string
+	// Value is the GraphQL literal of the keyword's value, e.g. "1".
+	Value string
+}
+
+// constraintKeywords lists the validation keywords turned into @constraint arguments, along with the type of the
+// argument, in the order they are written in.
+var constraintKeywords = []struct {
+	name     string
+	typeName string
+}{
+	{name: "minLength", typeName: "Int"},
+	{name: "maxLength", typeName: "Int"},
+	{name: "pattern", typeName: "String"},
+	{name: "minimum", typeName: "Float"},
+	{name: "maximum", typeName: "Float"},
+	{name: "exclusiveMinimum", typeName: "Float"},
+	{name: "exclusiveMaximum", typeName: "Float"},
+	{name: "multipleOf", typeName: "Float"},
+	{name: "minItems", typeName: "Int"},
+	{name: "maxItems", typeName: "Int"},
+	{name: "uniqueItems", typeName: "Boolean"},
+}
+
+// constraintDirective is the definition of the @constraint directive, generated whenever a field has constraints.
+var constraintDirective = func() string {
+	var sb strings.Builder
+	sb.WriteString("directive @constraint(\n")
+	for _, keyword := range constraintKeywords {
+		sb.WriteString(fmt.Sprintf("\t%s: %s\n", keyword.name, keyword.typeName))
+	}
+	sb.WriteString(") on FIELD_DEFINITION | INPUT_FIELD_DEFINITION | ARGUMENT_DEFINITION")
+
+	return sb.String()
+}()
+
+// withConstraints sets the constraints of every field built from the properties in root, out of their validation
+// keywords, when enabled through Options.Constraints.
+func (t *transformer) withConstraints(root any, fields []Field) error {
+	if !t.opts.Constraints {
+		return nil
+	}
+
+	for i, field := range fields {
+		property, err := getOrderedMapKey[any](root, field.Name)
+		if err != nil || *property == nil {
+			continue
+		}
+
+		constraints, err := constraints(*property)
+		if err != nil {
+			return fmt.Errorf("error on field %q: %w", field.Name, err)
+		}
+		fields[i].Constraints = constraints
+	}
+
+	return nil
+}
+
+// constraints reads the validation keywords of a property. Draft 4 boolean exclusiveMinimum and exclusiveMaximum
+// keywords, which only modify minimum and maximum, are skipped.
+func constraints(property any) ([]Constraint, error) {
+	var constraints []Constraint
+	for _, keyword := range constraintKeywords {
+		raw, err := getOrderedMapKey[any](property, keyword.name)
+		if err != nil {
+			return nil, err
+		}
+
+		var value string
+		switch v := (*raw).(type) {
+		case nil:
+			continue
+		case float64:
+			if keyword.typeName == "Int" {
+				value = strconv.FormatFloat(v, 'f', -1, 64)
+			} else if keyword.typeName == "Float" {
+				value = strconv.FormatFloat(v, 'g', -1, 64)
+			} else {
+				return nil, fmt.Errorf("%s must be a %s, got %v", keyword.name, keyword.typeName, v)
+			}
+		case string:
+			if keyword.typeName != "String" {
+				return nil, fmt.Errorf("%s must be a %s, got %q", keyword.name, keyword.typeName, v)
+			}
+			value = quote(v)
+		case bool:
+			if keyword.typeName != "Boolean" {
+				continue
+			}
+			value = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("%s must be a %s, got %v", keyword.name, keyword.typeName, v)
+		}
+
+		constraints = append(constraints, Constraint{Name: keyword.name, Value: value})
+	}
+
+	return constraints, nil
+}
@@ -0,0 +1,72 @@
+package graphql
+
+import (
+	"jgschema/jsonutils"
+	"reflect"
+	"testing"
+)
+
+func TestTransformConstraints(t *testing.T) {
+	type test struct {
+		description     string
+		constraints     bool
+		wantConstraints map[string][]Constraint // field name to its constraints
+	}
+
+	inputSchema := "./test_data/jsonschema/constraint-schema.json"
+	tests := []test{
+		{
+			description:     "should leave constraints out by default",
+			wantConstraints: map[string][]Constraint{},
+		},
+		{
+			description: "should turn validation keywords into constraints",
+			constraints: true,
+			wantConstraints: map[string][]Constraint{
+				"username": {
+					{Name: "minLength", Value: "3"},
+					{Name: "maxLength", Value: "20"},
+					{Name: "pattern", Value: `"^[a-z\\d]+$"`},
+				},
+				"age": {
+					{Name: "minimum", Value: "13"},
+					{Name: "exclusiveMaximum", Value: "150"},
+				},
+				"score": {
+					{Name: "multipleOf", Value: "0.5"},
+				},
+				"interests": {
+					{Name: "minItems", Value: "1"},
+					{Name: "maxItems", Value: "5"},
+					{Name: "uniqueItems", Value: "true"},
+				},
+				"nicknames": {
+					{Name: "maxItems", Value: "3"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", inputSchema, err)
+			}
+
+			schemas, err := TransformWithOptions(jsonSchema, inputSchema, Options{
+				RootTypeName: jsonSchema.Title,
+				Constraints:  test.constraints,
+			})
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			for _, field := range schemas[0].Fields {
+				if want := test.wantConstraints[field.Name]; !reflect.DeepEqual(want, field.Constraints) {
+					t.Errorf("field %q has constraints %v, want %v", field.Name, field.Constraints, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// withDefaults sets the default value of every field built from the properties in root, out of their "default"
+// keyword. The type of each field must already be known, since it decides how the value is written.
+func (t *transformer) withDefaults(root *orderedmap.OrderedMap, fields []Field) error {
+	for i, field := range fields {
+		property, ok := root.Get(field.Name)
+		if !ok {
+			continue
+		}
+
+		value, err := getOrderedMapKey[any](property, "default")
+		if err != nil {
+			return fmt.Errorf("error on field %q getting default: %w", field.Name, err)
+		}
+
+		if *value == nil {
+			continue
+		}
+
+		fields[i].Default, err = t.literal(*value, field.Type)
+		if err != nil {
+			return fmt.Errorf("error on field %q default: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// literal writes a raw JSON value as a GraphQL value literal of the type named typeName, or a list of that type.
+// Values of enums are written as the enum value they were turned into, and the fields of objects are written
+// according to the type of the matching field. Since defaults are only generated in input types, the fields of objects
+// their input type leaves out, such as read-only ones, are left out of the literal as well.
+func (t *transformer) literal(value any, typeName string) (string, error) {
+	schema := t.registry.types[Title(typeName)]
+
+	switch value := value.(type) {
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	case []any:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			literal, err := t.literal(item, typeName)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, literal)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case orderedmap.OrderedMap:
+		objectFields := map[string]Field{}
+		if schema != nil && (schema.Kind == KindObject || schema.Kind == KindInput) {
+			for _, field := range schema.Fields {
+				objectFields[field.Name] = field
+			}
+		}
+
+		var kinds map[string]SchemaKind
+		var withInput map[string]bool
+		if schema != nil && schema.Kind == KindObject {
+			registered := t.registeredSchemas()
+			kinds = schemaKinds(registered)
+			withInput = inputObjects(registered, kinds)
+		}
+
+		fields := make([]string, 0, len(value.Keys()))
+		for _, key := range value.Keys() {
+			field, ok := objectFields[key]
+			if !ok && schema != nil && schema.Kind != KindScalar {
+				return "", fmt.Errorf("type %q has no field %q", typeName, key)
+			}
+
+			if ok && schema.Kind == KindObject && !inInput(field, kinds, withInput) {
+				continue
+			}
+
+			raw, _ := value.Get(key)
+			literal, err := t.literal(raw, field.Type)
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s", key, literal))
+		}
+		return "{" + strings.Join(fields, ", ") + "}", nil
+	}
+
+	if schema != nil && schema.Kind == KindEnum {
+		return t.enumValueName(value)
+	}
+
+	switch value := value.(type) {
+	case string:
+		return quote(value), nil
+	case float64:
+		// Numbers are never written with an exponent: Int fields, and the scalars integers can be mapped to, can't
+		// take one, and Float fields take the plain form just as well.
+		return strconv.FormatFloat(value, 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("can't write the value %v of type %T as a GraphQL literal", value, value)
+	}
+}
+
+// registeredSchemas returns every type registered so far, leaving out the ones still being built.
+func (t *transformer) registeredSchemas() []Schema {
+	schemas := make([]Schema, 0, len(t.registry.types))
+	for _, schema := range t.registry.types {
+		if schema != nil {
+			schemas = append(schemas, *schema)
+		}
+	}
+
+	return schemas
+}
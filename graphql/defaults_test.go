@@ -0,0 +1,98 @@
+package graphql
+
+import (
+	"jgschema/jsonutils"
+	"testing"
+)
+
+func TestTransformDefaults(t *testing.T) {
+	type test struct {
+		description string
+		mapping     TypeMapping
+	}
+
+	inputSchema := "./test_data/jsonschema/default-schema.json"
+	wantDefaults := map[string]string{
+		"limit":  "20",
+		"ratio":  "0.5",
+		"query":  `"say \"hi\"\n"`,
+		"exact":  "false",
+		"sort":   "created_at",
+		"tags":   `["new", "sale"]`,
+		"filter": "{state: OPEN, minPrice: 1000000}",
+	}
+
+	tests := []test{
+		{description: "should write defaults as literals of the field types"},
+		{
+			description: "should write integer defaults without an exponent when integers are mapped",
+			mapping:     TypeMapping{Types: map[string]string{"integer": "Long"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", inputSchema, err)
+			}
+
+			schemas, err := TransformWithOptions(jsonSchema, inputSchema, Options{
+				RootTypeName: jsonSchema.Title,
+				TypeMapping:  test.mapping,
+			})
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			for _, schema := range schemas {
+				if schema.TypeName != "searchInput" {
+					continue
+				}
+
+				for _, field := range schema.Fields {
+					if want := wantDefaults[field.Name]; field.Default != want {
+						t.Errorf("field %q has default %q, want %q", field.Name, field.Default, want)
+					}
+				}
+				return
+			}
+
+			t.Errorf("no input type was generated for %q", jsonSchema.Title)
+		})
+	}
+}
+
+func TestLiteral(t *testing.T) {
+	type test struct {
+		description string
+		value       any
+		typeName    string
+		want        string
+	}
+
+	tests := []test{
+		{description: "should write whole floats without a fraction", value: 3.0, typeName: "number", want: "3"},
+		{description: "should never write floats with an exponent", value: 1e21, typeName: "number", want: "1000000000000000000000"},
+		{description: "should write small floats without an exponent", value: 1e-7, typeName: "number", want: "0.0000001"},
+		{description: "should never write mapped ints with an exponent", value: 1e6, typeName: "Long", want: "1000000"},
+		{description: "should never write ints with an exponent", value: 1e21, typeName: "integer", want: "1000000000000000000000"},
+		{description: "should escape control characters", value: "a\tb\u0001", typeName: "string", want: `"a\tb\u0001"`},
+		{description: "should write null", value: nil, typeName: "string", want: "null"},
+		{description: "should write nested lists", value: []any{[]any{1.0}, []any{}}, typeName: "integer", want: "[[1], []]"},
+	}
+
+	tr := &transformer{registry: newTypeRegistry()}
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got, err := tr.literal(test.value, test.typeName)
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
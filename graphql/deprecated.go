@@ -0,0 +1,61 @@
+package graphql
+
+import "fmt"
+
+const (
+	// deprecationReasonKeyword holds the reason a deprecated property is deprecated, taking precedence over its
+	// description.
+	deprecationReasonKeyword = "x-deprecation-reason"
+	// deprecatedEnumValuesKeyword maps the deprecated values of an enum to the reason they are deprecated, which can be
+	// empty.
+	deprecatedEnumValuesKeyword = "x-deprecated-enum-values"
+)
+
+// deprecation reads the "deprecated" keyword of a raw schema, along with the reason from the x-deprecation-reason
+// keyword.
+func deprecation(schema any) (bool, string, error) {
+	deprecated, err := getOrderedMapKey[bool](schema, "deprecated")
+	if err != nil {
+		return false, "", fmt.Errorf("error getting deprecated: %w", err)
+	}
+
+	reason, err := getOrderedMapKey[string](schema, deprecationReasonKeyword)
+	if err != nil {
+		return false, "", fmt.Errorf("error getting %s: %w", deprecationReasonKeyword, err)
+	}
+
+	return *deprecated, *reason, nil
+}
+
+// deprecate marks field as deprecated when deprecated is set. Without a reason, the description of the field is used.
+func deprecate(field *Field, deprecated bool, reason string) {
+	if !deprecated {
+		return
+	}
+
+	field.Deprecated = true
+	field.DeprecationReason = reason
+	if reason == "" {
+		field.DeprecationReason = field.Description
+	}
+}
+
+// deprecatedEnumValues reads the x-deprecated-enum-values keyword of a raw enum schema, mapping raw enum values to the
+// reason they are deprecated.
+func deprecatedEnumValues(schema any) (map[string]string, error) {
+	values, err := extractLeaf(schema, deprecatedEnumValuesKeyword)
+	if err != nil {
+		return nil, err
+	}
+
+	deprecated := map[string]string{}
+	for _, value := range values.Keys() {
+		reason, err := getOrderedMapKey[string](values, value)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the deprecation reason of enum value %q: %w", value, err)
+		}
+		deprecated[value] = *reason
+	}
+
+	return deprecated, nil
+}
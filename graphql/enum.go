@@ -0,0 +1,150 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// EnumValueStrategy determines how enum values that aren't valid GraphQL names are handled.
+type EnumValueStrategy int
+
+const (
+	// EnumValuesSanitize replaces characters that aren't allowed in a GraphQL name with underscores, and prepends an
+	// underscore to values that start with a digit or collide with true, false and null.
+	EnumValuesSanitize EnumValueStrategy = iota
+	// EnumValuesPrefix behaves like EnumValuesSanitize, but prepends Options.EnumValuePrefix instead of an underscore.
+	EnumValuesPrefix
+	// EnumValuesError fails the transformation when an enum value isn't a valid GraphQL name.
+	EnumValuesError
+)
+
+const defaultEnumValuePrefix = "VALUE_"
+
+// enumDescriptionsKeyword maps the values of an enum to their description, since JSON Schema has no keyword for it.
+const enumDescriptionsKeyword = "x-enum-descriptions"
+
+// EnumValue defines a single value of a GraphQL enum type.
+type EnumValue struct {
+	Name              string
+	Description       string
+	Deprecated        bool
+	DeprecationReason string
+}
+
+// buildEnum creates an enum schema out of the values of a JSON schema "enum" keyword.
+// A null value only marks the field as nullable, so it is left out of the enum.
+// deprecated maps raw values, as strings, to the reason they are deprecated, and descriptions to their description.
+func (t *transformer) buildEnum(typeName, description string, values []any, deprecated, descriptions map[string]string) (Schema, error) {
+	enum := Schema{
+		TypeName:    typeName,
+		Description: description,
+		Kind:        KindEnum,
+	}
+
+	seen := map[string]any{}
+	for _, value := range values {
+		if value == nil {
+			continue
+		}
+
+		name, err := t.enumValueName(value)
+		if err != nil {
+			return Schema{}, fmt.Errorf("error on enum %q: %w", typeName, err)
+		}
+
+		if previous, ok := seen[name]; ok {
+			return Schema{}, fmt.Errorf("enum %q values %v and %v both map to %q", typeName, previous, value, name)
+		}
+		seen[name] = value
+
+		reason, isDeprecated := deprecated[fmt.Sprint(value)]
+		enum.Values = append(enum.Values, EnumValue{
+			Name:              name,
+			Description:       descriptions[fmt.Sprint(value)],
+			Deprecated:        isDeprecated,
+			DeprecationReason: reason,
+		})
+	}
+
+	if len(enum.Values) == 0 {
+		return Schema{}, fmt.Errorf("enum %q has no values", typeName)
+	}
+
+	return enum, nil
+}
+
+// enumValueDescriptions reads the x-enum-descriptions keyword of a raw enum schema, mapping raw enum values to their
+// description.
+func enumValueDescriptions(schema any) (map[string]string, error) {
+	values, err := extractLeaf(schema, enumDescriptionsKeyword)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := map[string]string{}
+	for _, value := range values.Keys() {
+		description, err := getOrderedMapKey[string](values, value)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the description of enum value %q: %w", value, err)
+		}
+		descriptions[value] = *description
+	}
+
+	return descriptions, nil
+}
+
+// enumValueName turns a raw JSON enum value into a valid GraphQL enum value according to the configured strategy.
+func (t *transformer) enumValueName(value any) (string, error) {
+	raw := fmt.Sprint(value)
+	if isValidEnumValue(raw) {
+		return raw, nil
+	}
+
+	prefix := "_"
+	switch t.opts.EnumValueStrategy {
+	case EnumValuesSanitize:
+	case EnumValuesPrefix:
+		prefix = t.opts.EnumValuePrefix
+		if prefix == "" {
+			prefix = defaultEnumValuePrefix
+		}
+	case EnumValuesError:
+		return "", fmt.Errorf("%q is not a valid GraphQL enum value", raw)
+	default:
+		return "", fmt.Errorf("unknown enum value strategy %d", t.opts.EnumValueStrategy)
+	}
+
+	name := strings.Map(func(r rune) rune {
+		if isNameRune(r) {
+			return r
+		}
+		return '_'
+	}, raw)
+
+	if name == "" || unicode.IsDigit(rune(name[0])) || isReservedEnumValue(name) {
+		name = prefix + name
+	}
+
+	return name, nil
+}
+
+// isValidEnumValue reports whether str can be used as a GraphQL enum value without any changes.
+func isValidEnumValue(str string) bool {
+	if str == "" || unicode.IsDigit(rune(str[0])) || isReservedEnumValue(str) {
+		return false
+	}
+
+	for _, r := range str {
+		if !isNameRune(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isReservedEnumValue reports whether str is one of the names GraphQL forbids as an enum value.
+func isReservedEnumValue(str string) bool {
+	return str == "true" || str == "false" || str == "null"
+}
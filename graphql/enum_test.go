@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"testing"
+)
+
+func TestEnumValueName(t *testing.T) {
+	type test struct {
+		description string
+		options     Options
+		input       any
+		want        string
+		wantErr     bool
+	}
+
+	tests := []test{
+		{
+			description: "should keep a valid value as-is",
+			input:       "ACTIVE",
+			want:        "ACTIVE",
+		},
+		{
+			description: "should sanitize invalid characters",
+			input:       "in-progress",
+			want:        "in_progress",
+		},
+		{
+			description: "should prepend an underscore to a leading digit",
+			input:       float64(1),
+			want:        "_1",
+		},
+		{
+			description: "should prepend an underscore to reserved values",
+			input:       "null",
+			want:        "_null",
+		},
+		{
+			description: "should use the default prefix with the prefix strategy",
+			options:     Options{EnumValueStrategy: EnumValuesPrefix},
+			input:       "2fa",
+			want:        "VALUE_2fa",
+		},
+		{
+			description: "should use a custom prefix with the prefix strategy",
+			options:     Options{EnumValueStrategy: EnumValuesPrefix, EnumValuePrefix: "V"},
+			input:       "1.5",
+			want:        "V1_5",
+		},
+		{
+			description: "should fail on an invalid value with the error strategy",
+			options:     Options{EnumValueStrategy: EnumValuesError},
+			input:       "in progress",
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			tr := &transformer{opts: test.options}
+			got, err := tr.enumValueName(test.input)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error result, wantErr %v, got %v", test.wantErr, err)
+			}
+
+			if got != test.want {
+				t.Errorf("did not get expected enum value.\nwant - %q\ngot - %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildEnumDuplicateValues(t *testing.T) {
+	tr := &transformer{}
+	if _, err := tr.buildEnum("test", "", []any{"a-b", "a_b"}, nil, nil); err == nil {
+		t.Errorf("expected an error when two values sanitize to the same name")
+	}
+}
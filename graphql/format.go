@@ -0,0 +1,46 @@
+package graphql
+
+import "fmt"
+
+// DefaultFormats maps the JSON Schema "format" values with a commonly used GraphQL custom scalar to the name of that
+// scalar. It is used when Options.Formats is nil.
+var DefaultFormats = map[string]string{
+	"date-time": "DateTime",
+	"uuid":      "UUID",
+	"email":     "EmailAddress",
+	"uri":       "URL",
+}
+
+// formatType returns the type of a field whose JSON type is typeName, according to the "format" keyword of property.
+// A format found in the type mapping turns the field into the mapped type, and one found in the configured table into
+// that custom scalar, declared in schemas unless it is a built-in one.
+// Properties without a format, or with an unknown one, keep typeName.
+func (t *transformer) formatType(fieldName string, property any, typeName string, schemas *[]Schema) (string, error) {
+	format, err := getOrderedMapKey[string](property, "format")
+	if err != nil {
+		return "", fmt.Errorf("error getting format: %w", err)
+	}
+
+	if *format == "" {
+		return typeName, nil
+	}
+
+	formats := t.opts.Formats
+	if formats == nil {
+		formats = DefaultFormats
+	}
+
+	if mapped, ok := t.opts.TypeMapping.Formats[*format]; ok {
+		return t.mappedType(mapped), nil
+	}
+
+	scalar, ok := formats[*format]
+	if !ok {
+		if t.opts.OnUnknownFormat != nil {
+			t.opts.OnUnknownFormat(fieldName, *format)
+		}
+		return typeName, nil
+	}
+
+	return t.customScalar(scalar, schemas)
+}
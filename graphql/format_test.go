@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	"jgschema/jsonutils"
+	"reflect"
+	"testing"
+)
+
+func TestTransformFormats(t *testing.T) {
+	type test struct {
+		description string
+		formats     map[string]string
+		wantTypes   []string
+		wantFields  map[string]string // field name to the type it refers to
+		wantUnknown []string          // "field:format" for every unknown format reported
+	}
+
+	inputSchema := "./test_data/jsonschema/format-schema.json"
+	tests := []test{
+		{
+			description: "should map known formats to the default scalars, once each",
+			wantTypes:   []string{"event", "UUID", "DateTime", "URL"},
+			wantFields: map[string]string{
+				"id":       "UUID",
+				"startsAt": "DateTime",
+				"endsAt":   "DateTime",
+				"day":      "string",
+				"links":    "URL",
+			},
+			wantUnknown: []string{"day:date"},
+		},
+		{
+			description: "should use the configured formats instead of the defaults, without declaring built-in scalars",
+			formats:     map[string]string{"date": "Date", "uuid": "ID"},
+			wantTypes:   []string{"event", "Date"},
+			wantFields: map[string]string{
+				"id":       "ID",
+				"startsAt": "string",
+				"endsAt":   "string",
+				"day":      "Date",
+				"links":    "string",
+			},
+			wantUnknown: []string{"startsAt:date-time", "endsAt:date-time", "links:uri"},
+		},
+		{
+			description: "should keep every field a plain scalar with an empty table",
+			formats:     map[string]string{},
+			wantTypes:   []string{"event"},
+			wantFields: map[string]string{
+				"id":       "string",
+				"startsAt": "string",
+				"endsAt":   "string",
+				"day":      "string",
+				"links":    "string",
+			},
+			wantUnknown: []string{"id:uuid", "startsAt:date-time", "endsAt:date-time", "day:date", "links:uri"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", inputSchema, err)
+			}
+
+			var unknown []string
+			schemas, err := TransformWithOptions(jsonSchema, inputSchema, Options{
+				RootTypeName:    jsonSchema.Title,
+				Formats:         test.formats,
+				OnUnknownFormat: func(field, format string) { unknown = append(unknown, field+":"+format) },
+			})
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			var gotTypes []string
+			for _, schema := range schemas {
+				gotTypes = append(gotTypes, schema.TypeName)
+			}
+
+			if !reflect.DeepEqual(test.wantTypes, gotTypes) {
+				t.Errorf("did not get expected types.\nwant - %v\ngot - %v", test.wantTypes, gotTypes)
+			}
+
+			for _, field := range schemas[0].Fields {
+				if want := test.wantFields[field.Name]; field.Type != want {
+					t.Errorf("field %q refers to %q, want %q", field.Name, field.Type, want)
+				}
+			}
+
+			if !reflect.DeepEqual(test.wantUnknown, unknown) {
+				t.Errorf("did not get expected unknown formats.\nwant - %v\ngot - %v", test.wantUnknown, unknown)
+			}
+		})
+	}
+}
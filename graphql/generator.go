@@ -35,6 +35,7 @@ func GenerateToFile(schemas []Schema, path string, perms fs.FileMode) error {
 }
 
 // generate takes in a slice of GraphQL schemas and writes it in the format of a GraphQL schema file.
+// Custom scalars are declared once each at the top of the file, before every other type.
 // Final result is allocated to the passed in io.Writer.
 func generate(schemas []Schema, w io.Writer) error {
 	if w == nil {
@@ -43,28 +44,88 @@ func generate(schemas []Schema, w io.Writer) error {
 
 	var sb strings.Builder
 
+	scalars, schemas := splitScalars(schemas)
+	for _, scalar := range scalars {
+		sb.WriteString(fmt.Sprintf("scalar %s\n", Title(scalar)))
+	}
+	if len(scalars) > 0 && len(schemas) > 0 {
+		sb.WriteString("\n")
+	}
+
+	if hasConstraints(schemas) {
+		sb.WriteString(constraintDirective)
+		if len(schemas) > 0 {
+			sb.WriteString("\n\n")
+		}
+	}
+
 	for i, schema := range schemas {
 		if i != 0 && i != len(schemas) {
 			sb.WriteString("\n\n")
 		}
 
 		if schema.Description != "" {
-			sb.WriteString(fmt.Sprintf("\"%s\"\n", schema.Description))
+			sb.WriteString(description(schema.Description, ""))
+		}
+
+		if schema.Kind == KindEnum {
+			sb.WriteString(fmt.Sprintf("enum %s {\n", Title(schema.TypeName)))
+			for j, value := range schema.Values {
+				if j != 0 && value.Description != "" {
+					sb.WriteString("\n")
+				}
+				if value.Description != "" {
+					sb.WriteString(description(value.Description, "\t"))
+				}
+				sb.WriteString(fmt.Sprintf("\t%s%s\n", value.Name, deprecatedDirective(value.Deprecated, value.DeprecationReason)))
+			}
+			sb.WriteString("}")
+			continue
 		}
 
-		sb.WriteString(fmt.Sprintf("type %s {\n", title(schema.TypeName)))
+		if schema.Kind == KindUnion {
+			members := make([]string, 0, len(schema.Types))
+			for _, member := range schema.Types {
+				members = append(members, Title(member))
+			}
+			sb.WriteString(fmt.Sprintf("union %s = %s", Title(schema.TypeName), strings.Join(members, " | ")))
+			continue
+		}
+
+		keyword := "type"
+		switch schema.Kind {
+		case KindInput:
+			keyword = "input"
+		case KindInterface:
+			keyword = "interface"
+		}
+
+		var implements string
+		if len(schema.Interfaces) > 0 {
+			interfaces := make([]string, 0, len(schema.Interfaces))
+			for _, name := range schema.Interfaces {
+				interfaces = append(interfaces, Title(name))
+			}
+			implements = fmt.Sprintf(" implements %s", strings.Join(interfaces, " & "))
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %s%s {\n", keyword, Title(schema.TypeName), implements))
 		for j, field := range schema.Fields {
 			if j != 0 && j != len(schema.Fields) && field.Description != "" {
 				sb.WriteString("\n")
 			}
 			if field.Description != "" {
-				sb.WriteString(fmt.Sprintf("\t\"%s\"\n", field.Description))
+				sb.WriteString(description(field.Description, "\t"))
 			}
 			typeName, err := buildTypeRef(field)
 			if err != nil {
 				return fmt.Errorf("error building type ref in generate: %w", err)
 			}
-			sb.WriteString(fmt.Sprintf("\t%s: %s\n", field.Name, typeName))
+			// Only input types can have default values.
+			if schema.Kind == KindInput && field.Default != "" {
+				typeName = fmt.Sprintf("%s = %s", typeName, field.Default)
+			}
+			sb.WriteString(fmt.Sprintf("\t%s: %s%s%s\n", field.Name, typeName, constraintDirectiveUse(field.Constraints), deprecatedDirective(field.Deprecated, field.DeprecationReason)))
 		}
 
 		sb.WriteString("}")
@@ -74,14 +135,169 @@ func generate(schemas []Schema, w io.Writer) error {
 	return err
 }
 
-// buildTypeRef builds the type reference based on the name of the type, whether it is required, and whether it is an array.
+// hasConstraints reports whether any field of schemas has constraints, which requires the directive's definition.
+func hasConstraints(schemas []Schema) bool {
+	for _, schema := range schemas {
+		for _, field := range schema.Fields {
+			if len(field.Constraints) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// constraintDirectiveUse returns the @constraint directive, preceded by a space, for a field with constraints.
+// It is empty without constraints.
+func constraintDirectiveUse(constraints []Constraint) string {
+	if len(constraints) == 0 {
+		return ""
+	}
+
+	args := make([]string, 0, len(constraints))
+	for _, constraint := range constraints {
+		args = append(args, fmt.Sprintf("%s: %s", constraint.Name, constraint.Value))
+	}
+
+	return fmt.Sprintf(" @constraint(%s)", strings.Join(args, ", "))
+}
+
+// deprecatedDirective returns the @deprecated directive, preceded by a space, for a deprecated field or enum value.
+// It is empty when deprecated isn't set.
+func deprecatedDirective(deprecated bool, reason string) string {
+	if !deprecated {
+		return ""
+	}
+
+	if reason == "" {
+		return " @deprecated"
+	}
+
+	return fmt.Sprintf(" @deprecated(reason: %s)", quote(reason))
+}
+
+// description returns the description of a type or field, on its own line with the given indentation. Multi-line
+// descriptions are written as block strings, which keep them readable, unless the block string would alter them.
+func description(str, indent string) string {
+	if !isBlockStringSafe(str) {
+		return fmt.Sprintf("%s%s\n", indent, quote(str))
+	}
+
+	var sb strings.Builder
+	sb.WriteString(indent + `"""` + "\n")
+	for _, line := range strings.Split(str, "\n") {
+		if line != "" {
+			sb.WriteString(indent + strings.ReplaceAll(line, `"""`, `\"""`))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString(indent + `"""` + "\n")
+
+	return sb.String()
+}
+
+// isBlockStringSafe reports whether str is a multi-line string that reads the same once written as a block string.
+// Block strings drop leading and trailing blank lines and the indentation common to every line, and can't hold
+// control characters other than tabs.
+func isBlockStringSafe(str string) bool {
+	if !strings.Contains(str, "\n") {
+		return false
+	}
+
+	lines := strings.Split(str, "\n")
+	if strings.TrimSpace(lines[0]) == "" || strings.TrimSpace(lines[len(lines)-1]) == "" {
+		return false
+	}
+
+	indented := true
+	for _, line := range lines {
+		if line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			indented = false
+		}
+
+		for _, r := range line {
+			if (r < 0x20 && r != '\t') || r == 0x7f {
+				return false
+			}
+		}
+	}
+
+	return !indented
+}
+
+// quote returns str as a GraphQL string value, escaping quotes, backslashes and control characters.
+func quote(str string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range str {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\b':
+			sb.WriteString(`\b`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				sb.WriteString(fmt.Sprintf(`\u%04X`, r))
+				continue
+			}
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
+
+// splitScalars returns the names of the custom scalars in schemas, without duplicates, and the remaining schemas.
+// Schemas transformed from several files can each declare the same scalar.
+func splitScalars(schemas []Schema) ([]string, []Schema) {
+	var scalars []string
+	others := make([]Schema, 0, len(schemas))
+	seen := map[string]bool{}
+	for _, schema := range schemas {
+		if schema.Kind != KindScalar {
+			others = append(others, schema)
+			continue
+		}
+
+		if !seen[Title(schema.TypeName)] {
+			seen[Title(schema.TypeName)] = true
+			scalars = append(scalars, schema.TypeName)
+		}
+	}
+
+	return scalars, others
+}
+
+// TypeRef returns the GraphQL type reference of the field as it is generated, e.g. [String!]!.
+func (f Field) TypeRef() (string, error) {
+	return buildTypeRef(f)
+}
+
+// buildTypeRef builds the type reference based on the name of the type, whether it is required, and the lists it is
+// wrapped in, e.g. [[Float!]!].
 func buildTypeRef(field Field) (string, error) {
 	builtType, err := constructFieldName(field.Name, field.Type)
 	if err != nil {
 		return "", fmt.Errorf("error building type reference for field %q: %w", field.Name, err)
 	}
 
-	if field.Array {
+	// Lists are wrapped from the innermost one outwards.
+	for level := field.listDepth() - 1; level >= 0; level-- {
+		if level < len(field.ItemsRequired) && field.ItemsRequired[level] {
+			builtType = fmt.Sprintf("%s!", builtType)
+		}
 		builtType = fmt.Sprintf("[%s]", builtType)
 	}
 
@@ -104,8 +320,10 @@ func constructFieldName(name string, typeName string) (string, error) {
 	case "string":
 		return "String", nil
 	case "array":
-		return "[]", nil
+		return "", fmt.Errorf("lists are built from the type of their items, which is unknown")
+	case "":
+		return "", fmt.Errorf("the field has no type")
 	default:
-		return title(typeName), nil
+		return Title(typeName), nil
 	}
 }
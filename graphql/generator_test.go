@@ -97,6 +97,286 @@ func TestGenerate(t *testing.T) {
 			},
 			wantSchema: fmt.Sprintf("%s/array-required-fields.graphql", schemaTestDir),
 		},
+		{
+			description: "Should successfully generate enums alongside object types.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "Test",
+					Fields: []Field{
+						{
+							Name:        "status",
+							Description: "Test status.",
+							Type:        "Status",
+							Required:    true,
+						},
+					},
+				},
+				{
+					TypeName:    "Status",
+					Description: "Possible statuses.",
+					Kind:        KindEnum,
+					Values:      []EnumValue{{Name: "ACTIVE"}, {Name: "DISABLED", Description: "No longer in use."}},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/enum-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should successfully generate a union referenced by a field.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "Test",
+					Fields: []Field{
+						{
+							Name:        "payment",
+							Description: "Test payment.",
+							Type:        "Payment",
+						},
+					},
+				},
+				{
+					TypeName: "Card",
+					Fields:   []Field{{Name: "number", Type: "String"}},
+				},
+				{
+					TypeName: "BankTransfer",
+					Fields:   []Field{{Name: "iban", Type: "String"}},
+				},
+				{
+					TypeName: "Payment",
+					Kind:     KindUnion,
+					Types:    []string{"Card", "BankTransfer"},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/union-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should successfully generate input types.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "Test",
+					Fields: []Field{
+						{
+							Name:        "testObject",
+							Description: "Test object.",
+							Type:        "TestObject",
+						},
+					},
+				},
+				{
+					TypeName: "TestInput",
+					Kind:     KindInput,
+					Fields: []Field{
+						{
+							Name:        "testObject",
+							Description: "Test object.",
+							Type:        "TestObjectInput",
+							Required:    true,
+						},
+					},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/input-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should add constraint directives along with their definition.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "Signup",
+					Fields: []Field{
+						{
+							Name: "username",
+							Type: "string",
+							Constraints: []Constraint{
+								{Name: "minLength", Value: "3"},
+								{Name: "pattern", Value: `"^[a-z\\d]+$"`},
+							},
+							Deprecated: true,
+						},
+						{Name: "bio", Type: "string"},
+					},
+				},
+				{
+					TypeName: "SignupInput",
+					Kind:     KindInput,
+					Fields: []Field{
+						{
+							Name: "username",
+							Type: "string",
+							Constraints: []Constraint{
+								{Name: "minLength", Value: "3"},
+								{Name: "pattern", Value: `"^[a-z\\d]+$"`},
+							},
+						},
+					},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/constraint-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should escape descriptions and write multi-line ones as block strings.",
+			inputGraphQL: []Schema{
+				{
+					TypeName:    "Test",
+					Description: `A "quoted" name with a \ backslash.`,
+					Fields: []Field{
+						{
+							Name:        "testField",
+							Description: "First line.\nSecond line with a \"\"\" quote.",
+							Type:        "String",
+						},
+						{
+							Name:        "otherField",
+							Description: "Tab\tand a bell \a.",
+							Type:        "Int",
+						},
+					},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/description-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should only generate default values in input types.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "Search",
+					Fields: []Field{
+						{Name: "limit", Type: "integer", Default: "20"},
+						{Name: "ratio", Type: "number", Default: "0.5"},
+					},
+				},
+				{
+					TypeName: "SearchInput",
+					Kind:     KindInput,
+					Fields: []Field{
+						{Name: "limit", Type: "integer", Default: "20"},
+						{Name: "ratio", Type: "number", Default: "0.5"},
+						{Name: "query", Type: "string", Default: `"say \"hi\"\n"`},
+						{Name: "filter", Type: "FilterInput", Default: "{state: OPEN, minPrice: 1000000}"},
+					},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/default-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should add the deprecated directive to fields and enum values.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "Test",
+					Fields: []Field{
+						{
+							Name:              "fullName",
+							Description:       "Use firstName and lastName instead.",
+							Type:              "string",
+							Deprecated:        true,
+							DeprecationReason: "Use firstName and lastName instead.",
+						},
+						{
+							Name:              "legacyId",
+							Type:              "integer",
+							Deprecated:        true,
+							DeprecationReason: `Use "id" instead.`,
+						},
+						{
+							Name:       "oldField",
+							Type:       "string",
+							Deprecated: true,
+						},
+					},
+				},
+				{
+					TypeName: "Status",
+					Kind:     KindEnum,
+					Values: []EnumValue{
+						{Name: "WAITING"},
+						{Name: "PENDING", Deprecated: true, DeprecationReason: "Use WAITING instead."},
+					},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/deprecated-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should wrap nested lists and non-null items.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "Test",
+					Fields: []Field{
+						{
+							Name:          "matrix",
+							Type:          "number",
+							Array:         true,
+							ListDepth:     2,
+							ItemsRequired: []bool{true, true},
+						},
+						{
+							Name:      "cubes",
+							Type:      "Cube",
+							Array:     true,
+							ListDepth: 3,
+							Required:  true,
+						},
+						{
+							Name:          "tags",
+							Type:          "string",
+							Array:         true,
+							ItemsRequired: []bool{true},
+						},
+					},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/nested-list-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should declare every custom scalar once at the top of the schema.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "Event",
+					Fields: []Field{
+						{
+							Name:     "id",
+							Type:     "UUID",
+							Required: true,
+						},
+						{
+							Name:        "startsAt",
+							Description: "When the event starts.",
+							Type:        "DateTime",
+						},
+					},
+				},
+				{TypeName: "DateTime", Kind: KindScalar},
+				{TypeName: "UUID", Kind: KindScalar},
+				{
+					TypeName: "Occurrence",
+					Fields:   []Field{{Name: "at", Type: "DateTime"}},
+				},
+				{TypeName: "DateTime", Kind: KindScalar},
+			},
+			wantSchema: fmt.Sprintf("%s/scalar-schema.graphql", schemaTestDir),
+		},
+		{
+			description: "Should successfully generate interfaces and the interfaces types implement.",
+			inputGraphQL: []Schema{
+				{
+					TypeName: "node",
+					Kind:     KindInterface,
+					Fields:   []Field{{Name: "id", Type: "ID", Required: true}},
+				},
+				{
+					TypeName: "named",
+					Kind:     KindInterface,
+					Fields:   []Field{{Name: "name", Type: "string"}},
+				},
+				{
+					TypeName:   "person",
+					Interfaces: []string{"node", "named"},
+					Fields: []Field{
+						{Name: "id", Type: "ID", Required: true},
+						{Name: "name", Type: "string"},
+					},
+				},
+			},
+			wantSchema: fmt.Sprintf("%s/interface-schema.graphql", schemaTestDir),
+		},
 	}
 
 	for _, test := range tests {
@@ -128,6 +408,84 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestGenerateErrors(t *testing.T) {
+	type test struct {
+		description  string
+		inputGraphQL []Schema
+		wantErr      error
+	}
+
+	tests := []test{
+		{
+			description: "should fail on a field without a type",
+			inputGraphQL: []Schema{
+				{TypeName: "Event", Fields: []Field{{Name: "meta"}}},
+			},
+			wantErr: fmt.Errorf("error generating graphql schema: error building type ref in generate: error building type reference for field \"meta\": the field has no type"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			_, err := Generate(test.inputGraphQL)
+			if err == nil {
+				t.Fatalf("expected the following error, but did not get any error: %v", test.wantErr)
+			}
+
+			if test.wantErr.Error() != err.Error() {
+				t.Errorf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDescription(t *testing.T) {
+	type test struct {
+		description string
+		input       string
+		indent      string
+		want        string
+	}
+
+	tests := []test{
+		{
+			description: "should quote single line descriptions",
+			input:       `say "hi" \ bye`,
+			indent:      "\t",
+			want:        "\t\"say \\\"hi\\\" \\\\ bye\"\n",
+		},
+		{
+			description: "should write multi-line descriptions as indented block strings",
+			input:       "First.\n\n  Indented.",
+			indent:      "\t",
+			want:        "\t\"\"\"\n\tFirst.\n\n\t  Indented.\n\t\"\"\"\n",
+		},
+		{
+			description: "should quote multi-line descriptions a block string would change",
+			input:       "  both lines\n  are indented",
+			want:        "\"  both lines\\n  are indented\"\n",
+		},
+		{
+			description: "should quote multi-line descriptions with leading blank lines",
+			input:       "\nsecond",
+			want:        "\"\\nsecond\"\n",
+		},
+		{
+			description: "should quote multi-line descriptions with carriage returns",
+			input:       "first\r\nsecond",
+			want:        "\"first\\r\\nsecond\"\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			if got := description(test.input, test.indent); got != test.want {
+				t.Errorf("did not get expected description.\nwant - %q\ngot - %q", test.want, got)
+			}
+		})
+	}
+}
+
 func cleanUpFileContents(contents string) string {
 	for _, char := range []string{"\n", "\t", " "} {
 		contents = strings.ReplaceAll(contents, char, "")
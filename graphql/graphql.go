@@ -2,7 +2,6 @@ package graphql
 
 import (
 	"fmt"
-	"path/filepath"
 
 	"github.com/iancoleman/orderedmap"
 	"github.com/invopop/jsonschema"
@@ -12,13 +11,39 @@ const (
 	typeObject = "object"
 	typeRoot   = "root"
 	typeArray  = "array"
+	typeNull   = "null"
+)
+
+// SchemaKind identifies which kind of GraphQL type declaration a Schema represents.
+type SchemaKind int
+
+const (
+	// KindObject is a GraphQL object type, declared with the "type" keyword.
+	KindObject SchemaKind = iota
+	// KindEnum is a GraphQL enum type.
+	KindEnum
+	// KindUnion is a GraphQL union type.
+	KindUnion
+	// KindInput is a GraphQL input object type, declared with the "input" keyword.
+	KindInput
+	// KindScalar is a GraphQL custom scalar, such as the ones JSON Schema formats are mapped to.
+	KindScalar
+	// KindInterface is a GraphQL interface type. Transformed JSON schemas never have any, but parsed SDL can.
+	KindInterface
 )
 
 // Schema defines the elements of a GraphQL schema in the context of this program.
 type Schema struct {
 	TypeName    string
 	Description string
+	Kind        SchemaKind
 	Fields      []Field
+	// Values holds the values of an enum, and is only used when Kind is KindEnum.
+	Values []EnumValue
+	// Types holds the names of the member types of a union, and is only used when Kind is KindUnion.
+	Types []string
+	// Interfaces holds the names of the interfaces an object or interface type implements.
+	Interfaces []string
 }
 
 // Field defines the data needed to construct a GraphQL schema field.
@@ -26,28 +51,152 @@ type Field struct {
 	Name        string
 	Type        string
 	Description string
-	Required    bool
-	Array       bool
+	// Required makes the field non-null. For lists, it only applies to the list itself, see ItemsRequired for its items.
+	Required bool
+	Array    bool
+	// ListDepth is the number of lists wrapping Type when Array is set, and is only set for nested lists, e.g. 2 for
+	// [[Float]]. Zero means a single list.
+	ListDepth int
+	// ItemsRequired reports, from the outermost list inwards, whether the items of each list are non-null. Missing
+	// entries are nullable, and it is nil when every item is.
+	ItemsRequired []bool
+	// ReadOnly fields are left out of input types, e.g. a server-assigned id.
+	ReadOnly bool
+	// WriteOnly fields are left out of object types when input types are generated, e.g. a password.
+	WriteOnly bool
+	// Deprecated fields are generated with the @deprecated directive, along with DeprecationReason when it is set.
+	Deprecated        bool
+	DeprecationReason string
+	// Default is the GraphQL literal of the field's default value, e.g. "20", which is only generated in input types.
+	Default string
+	// Constraints are generated as the arguments of a @constraint directive.
+	Constraints []Constraint
+}
+
+// RootNameSource determines where the root type name is derived from when no explicit name is given.
+type RootNameSource int
+
+const (
+	// RootNameFromFile derives the root type name from the schema's file name, without the extension.
+	RootNameFromFile RootNameSource = iota
+	// RootNameFromTitle derives the root type name from the schema's "title" keyword.
+	RootNameFromTitle
+)
+
+// Options configures how a JSON schema is transformed into GraphQL schemas.
+// The zero value matches the behavior of Transform.
+type Options struct {
+	// RootTypeName is used as-is for the root type when set.
+	RootTypeName string
+	// RootNameSource picks what the root type name is derived from when RootTypeName is empty.
+	RootNameSource RootNameSource
+	// NameFunc turns the file name or title picked by RootNameSource into a type name.
+	// By default, the value is converted into a valid GraphQL name, e.g. "my-schema" becomes "mySchema".
+	NameFunc func(string) string
+	// UnionMode determines whether oneOf and anyOf are merged into the parent or turned into GraphQL unions.
+	UnionMode UnionMode
+	// MultiTypeStrategy determines how properties allowing several non-null types, e.g. ["string", "integer"], are handled.
+	MultiTypeStrategy MultiTypeStrategy
+	// NameConflictStrategy determines how two different types wanting the same name are told apart.
+	NameConflictStrategy NameConflictStrategy
+	// OnRename, when set, is called for every type renamed because of a name conflict.
+	OnRename func(Rename)
+	// InputTypes adds an input type, named after the object type followed by "Input", for every object type.
+	// A schema can override this with the "x-graphql-input" boolean keyword at its root.
+	// Read-only fields are left out of input types, and write-only fields out of object types.
+	InputTypes bool
+	// OnSkippedInputField, when set, is called for every field left out of the input type of typeName because it
+	// refers to a union, which input types can't use. Required fields are reported too, even though clients can't
+	// send them anymore.
+	OnSkippedInputField func(typeName string, field Field)
+	// EnumValueStrategy determines how enum values that aren't valid GraphQL names are handled.
+	EnumValueStrategy EnumValueStrategy
+	// EnumValuePrefix is prepended to enum values that don't start with a letter or underscore when
+	// EnumValueStrategy is EnumValuesPrefix. Defaults to "VALUE_".
+	EnumValuePrefix string
+	// Formats maps JSON Schema "format" values to the name of the GraphQL custom scalar used for them.
+	// DefaultFormats is used when nil, while an empty map keeps every format a plain scalar such as String.
+	Formats map[string]string
+	// OnUnknownFormat, when set, is called for every field whose format isn't in Formats. The field keeps its JSON type.
+	OnUnknownFormat func(field string, format string)
+	// TypeMapping overrides the GraphQL type of fields by JSON type, format, $ref or property path.
+	TypeMapping TypeMapping
+	// MapStrategy determines how free-form objects, such as dictionaries described through "additionalProperties",
+	// are represented.
+	MapStrategy MapStrategy
+	// MapScalar is the name of the custom scalar used for free-form objects. Defaults to "JSON".
+	MapScalar string
+	// ItemNullability determines whether the items of lists are non-null.
+	ItemNullability ItemNullability
+	// MinItemsNonNull makes lists with a minItems of at least one non-null, even when they aren't required.
+	MinItemsNonNull bool
+	// TupleStrategy determines how tuples whose positions have different schemas are transformed.
+	TupleStrategy TupleStrategy
+	// Constraints adds a @constraint directive, along with its definition, to fields with validation keywords such as
+	// minLength, pattern or maximum, for servers able to enforce them. Validation keywords of array items are dropped.
+	Constraints bool
+}
+
+// transformer holds the configuration and state shared by every step of a single transform run.
+type transformer struct {
+	opts Options
+	// documents caches every schema file read during the run by path.
+	documents map[string]*document
+	// refs maps the id of every referenced location that was already turned into a type to the name of that type.
+	refs map[string]string
+	// registry holds the name of every type generated so far.
+	registry *typeRegistry
+	// parents is the stack of types being walked, the last one being the innermost.
+	parents []string
+	// path holds the names of the properties leading to the object being walked, used to match TypeMapping.Paths.
+	path []string
+	// mapped holds every type name handed out by the type mapping, declared by declareMappedTypes once the walk is done.
+	mapped []string
 }
 
 // Transform is a public wrapper around transform, where an already made jsonschema.Schema is used.
 func Transform(jsonSchema *jsonschema.Schema, schemaPath string) ([]Schema, error) {
-	return transform(jsonSchema, schemaPath, "") // TODO: pass in custom schema name
+	return transform(jsonSchema, schemaPath, Options{})
+}
+
+// TransformWithOptions behaves like Transform, but lets the caller configure the transformation through opts.
+func TransformWithOptions(jsonSchema *jsonschema.Schema, schemaPath string, opts Options) ([]Schema, error) {
+	return transform(jsonSchema, schemaPath, opts)
 }
 
 // transform handles the logic of transforming a given jsonschema.Schema struct into a GraphQL schema struct.
 // By default, the file name of the schema (without the extension) with the first letter uppercased will be used as the root schema title.
-// You can pass in a value for customRootTitle to bypass this default.
-func transform(jsonSchema *jsonschema.Schema, schemaPath string, customRootTitle string) ([]Schema, error) {
+// See Options for the ways this default can be bypassed.
+func transform(jsonSchema *jsonschema.Schema, schemaPath string, opts Options) ([]Schema, error) {
 	if jsonSchema.Title == "" {
 		return nil, fmt.Errorf("please provide a title for the schema")
 	}
 
-	parentSchemaTitle := customRootTitle
-	if parentSchemaTitle == "" {
-		parentSchemaTitle = fileNameNoExtension(schemaPath)
+	parentSchemaTitle, err := rootTypeName(jsonSchema, schemaPath, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &transformer{
+		opts:      opts,
+		documents: map[string]*document{},
+		refs:      map[string]string{},
+		registry:  newTypeRegistry(),
+		parents:   []string{parentSchemaTitle},
+	}
+
+	if _, err := t.reserveName(parentSchemaTitle); err != nil {
+		return nil, err
 	}
 
+	doc, err := t.rootDocument(jsonSchema, schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading schema document: %w", err)
+	}
+
+	// Refs back to the root of the schema refer to the parent type.
+	t.refs[refID(doc, "")] = parentSchemaTitle
+
 	parent := Schema{
 		TypeName:    parentSchemaTitle,
 		Description: jsonSchema.Description,
@@ -56,33 +205,46 @@ func transform(jsonSchema *jsonschema.Schema, schemaPath string, customRootTitle
 
 	schemas := []Schema{{}}
 
-	schemaPath = filepath.Dir(schemaPath)
-
 	// To go down the properties tree, we will begin a recursive walk.
-	if err := walk(jsonSchema.Properties, jsonSchema.Required, &parent, &schemas, typeRoot, jsonSchema.Definitions, schemaPath); err != nil {
-		return nil, fmt.Errorf("error when walking down the properties tree: %w", err)
+	// Schemas made up only of allOf, oneOf or anyOf have no properties to walk.
+	if jsonSchema.Properties != nil {
+		if err := t.walk(jsonSchema.Properties, jsonSchema.Required, &parent, &schemas, typeRoot, doc); err != nil {
+			return nil, fmt.Errorf("error when walking down the properties tree: %w", err)
+		}
 	}
 
 	if jsonSchema.AllOf != nil {
 		for _, allOf := range jsonSchema.AllOf {
-			ref, err := getRef(allOf.Ref, "$defs", schemaPath, jsonSchema.Definitions)
+			ref, err := t.getRef(allOf.Ref, doc)
 			if err != nil {
 				return nil, fmt.Errorf("error getting allOf ref %q: %w", allOf.Ref, err)
 			}
-			err = walkRef(ref, &parent, &schemas, schemaPath)
+			err = t.walkRef(ref, lowerTitle(ref.schema.Title), &parent, &schemas)
 			if err != nil {
 				return nil, fmt.Errorf("error processing allOf schema %q: %w", allOf.Ref, err)
 			}
 		}
 	}
 
+	if t.opts.UnionMode == UnionsAsTypes {
+		if err := t.rootUnions(jsonSchema, &parent, &schemas, doc); err != nil {
+			return nil, err
+		}
+
+		schemas[0] = parent
+		if err := t.declareMappedTypes(&schemas); err != nil {
+			return nil, err
+		}
+		return t.withInputTypes(schemas, doc)
+	}
+
 	if jsonSchema.OneOf != nil {
 		for _, oneOf := range jsonSchema.OneOf {
-			ref, err := getRef(oneOf.Ref, "$defs", schemaPath, jsonSchema.Definitions)
+			ref, err := t.getRef(oneOf.Ref, doc)
 			if err != nil {
 				return nil, fmt.Errorf("error getting oneOf ref %q: %w", oneOf.Ref, err)
 			}
-			err = walkRef(ref, &parent, &schemas, schemaPath)
+			err = t.walkRef(ref, lowerTitle(ref.schema.Title), &parent, &schemas)
 			if err != nil {
 				return nil, fmt.Errorf("error processing oneOf schema %q: %w", oneOf.Ref, err)
 			}
@@ -91,11 +253,11 @@ func transform(jsonSchema *jsonschema.Schema, schemaPath string, customRootTitle
 
 	if jsonSchema.AnyOf != nil {
 		for _, anyOf := range jsonSchema.AnyOf {
-			ref, err := getRef(anyOf.Ref, "$defs", schemaPath, jsonSchema.Definitions)
+			ref, err := t.getRef(anyOf.Ref, doc)
 			if err != nil {
 				return nil, fmt.Errorf("error getting anyOf ref %q: %w", anyOf.Ref, err)
 			}
-			err = walkRef(ref, &parent, &schemas, schemaPath)
+			err = t.walkRef(ref, lowerTitle(ref.schema.Title), &parent, &schemas)
 			if err != nil {
 				return nil, fmt.Errorf("error processing anyOf schema %q: %w", anyOf.Ref, err)
 			}
@@ -103,36 +265,91 @@ func transform(jsonSchema *jsonschema.Schema, schemaPath string, customRootTitle
 	}
 
 	schemas[0] = parent
-	return schemas, nil
+	if err := t.declareMappedTypes(&schemas); err != nil {
+		return nil, err
+	}
+	return t.withInputTypes(schemas, doc)
+}
+
+// rootTypeName resolves the name of the root type according to the passed in options.
+func rootTypeName(jsonSchema *jsonschema.Schema, schemaPath string, opts Options) (string, error) {
+	if opts.RootTypeName != "" {
+		return opts.RootTypeName, nil
+	}
+
+	var base string
+	switch opts.RootNameSource {
+	case RootNameFromFile:
+		base = fileNameNoExtension(schemaPath)
+	case RootNameFromTitle:
+		base = jsonSchema.Title
+	default:
+		return "", fmt.Errorf("unknown root name source %d", opts.RootNameSource)
+	}
+
+	nameFunc := opts.NameFunc
+	if nameFunc == nil {
+		nameFunc = toTypeName
+	}
+
+	name := nameFunc(base)
+	if name == "" {
+		return "", fmt.Errorf("could not derive a root type name from %q", base)
+	}
+
+	return name, nil
 }
 
 // walk facilitates the different node types (top of the schema, objects, arrays, etc.) and walks down whatever tree
 // that comes from the passed in node.
-func walk(node any, required []string, parent *Schema, schemas *[]Schema, typeName string, definitions jsonschema.Definitions, schemaPath string) error {
+func (t *transformer) walk(node any, required []string, parent *Schema, schemas *[]Schema, typeName string, doc *document) error {
 	switch typeName {
 	case typeRoot:
 		rootOrderedMap, ok := node.(*orderedmap.OrderedMap)
 		if !ok {
 			return fmt.Errorf("error asserting orderedMap on root node")
 		}
-		return walkObject(rootOrderedMap, parent, schemas, required, definitions, schemaPath)
+		return t.walkObject(rootOrderedMap, parent, schemas, required, doc)
 	case typeObject:
 		properties, err := extractLeaf(node, "properties")
 		if err != nil {
 			return fmt.Errorf("error getting properties declaration: %w", err)
 		}
-		return walkObject(properties, parent, schemas, required, definitions, schemaPath)
+		return t.walkObject(properties, parent, schemas, required, doc)
 	case typeArray:
 		items, err := extractLeaf(node, "items")
 		if err != nil {
 			return fmt.Errorf("error getting items declaration: %w", err)
 		}
-		return walkArray(items, parent, schemas, definitions, schemaPath)
+
+		// Nullable items can be wrapped the same way nullable properties are, see nullableBranch.
+		branch, wrapped := nullableBranch(items)
+		if wrapped {
+			items = branch
+		}
+
+		if err := t.walkArray(items, parent, schemas, doc); err != nil {
+			return err
+		}
+
+		// The item added by walkArray is only required when its items schema says so.
+		if len(parent.Fields) > 0 {
+			required, err := t.itemsRequired(items)
+			parent.Fields[len(parent.Fields)-1].Required = required && !wrapped
+			return err
+		}
+		return nil
 	}
 	return nil
 }
 
-func walkObject(root *orderedmap.OrderedMap, parent *Schema, schemas *[]Schema, requiredFields []string, definitions jsonschema.Definitions, schemaPath string) error {
+func (t *transformer) walkObject(root *orderedmap.OrderedMap, parent *Schema, schemas *[]Schema, requiredFields []string, doc *document) error {
+	t.parents = append(t.parents, parent.TypeName)
+	defer func() { t.parents = t.parents[:len(t.parents)-1] }()
+
+	// Fields walked down below start at this index, the parent could already have fields from elsewhere.
+	first := len(parent.Fields)
+
 	// .Keys() will contain the list of fields from a properties declaration.
 	for _, key := range root.Keys() {
 		schema := Schema{Fields: []Field{}}
@@ -143,25 +360,112 @@ func walkObject(root *orderedmap.OrderedMap, parent *Schema, schemas *[]Schema,
 
 		schema.TypeName = key
 
+		// Nullable references come wrapped into an anyOf with a null schema, which only makes the field nullable.
+		branch, wrapped := nullableBranch(property)
+		if wrapped {
+			property = *branch
+		}
+
+		readOnly, err := getOrderedMapKey[bool](property, "readOnly")
+		if err != nil {
+			return fmt.Errorf("error on field %q getting readOnly: %w", key, err)
+		}
+
+		writeOnly, err := getOrderedMapKey[bool](property, "writeOnly")
+		if err != nil {
+			return fmt.Errorf("error on field %q getting writeOnly: %w", key, err)
+		}
+
+		deprecated, reason, err := deprecation(property)
+		if err != nil {
+			return fmt.Errorf("error on field %q: %w", key, err)
+		}
+
+		if typeName, ok := t.opts.TypeMapping.Paths[t.propertyPath(key)]; ok {
+			field, err := t.mappedField(key, property, typeName, requiredFields)
+			if err != nil {
+				return err
+			}
+
+			field.Required = field.Required && !wrapped
+			field.ReadOnly, field.WriteOnly = *readOnly, *writeOnly
+			deprecate(&field, deprecated, reason)
+			parent.Fields = append(parent.Fields, field)
+			continue
+		}
+
 		potentialRef, _ := getOrderedMapKey[string](property, "$ref")
+		if typeName, ok := t.opts.TypeMapping.Refs[*potentialRef]; ok && *potentialRef != "" {
+			field, err := t.mappedField(key, property, typeName, requiredFields)
+			if err != nil {
+				return err
+			}
+
+			field.Required = field.Required && !wrapped
+			field.ReadOnly, field.WriteOnly = *readOnly, *writeOnly
+			deprecate(&field, deprecated, reason)
+			parent.Fields = append(parent.Fields, field)
+			continue
+		}
+
 		if potentialRef != nil && *potentialRef != "" {
-			// TODO: remove hard-coded $defs
-			ref, err := getRef(*potentialRef, "$defs", schemaPath, definitions)
+			ref, err := t.getRef(*potentialRef, doc)
 			if err != nil {
 				return fmt.Errorf("error getting ref with path %q: %w", *potentialRef, err)
 			}
 
-			if err := walkRef(ref, parent, schemas, schemaPath); err != nil {
-				return fmt.Errorf("error processing ref at %q", key)
+			// The keywords can be set next to the $ref, or in the referenced schema itself.
+			*readOnly = *readOnly || ref.schema.ReadOnly
+			*writeOnly = *writeOnly || ref.schema.WriteOnly
+			if !deprecated {
+				deprecated, reason, err = deprecation(ref.raw)
+				if err != nil {
+					return fmt.Errorf("error on ref %q: %w", *potentialRef, err)
+				}
 			}
 
+			if len(ref.schema.Enum) > 0 {
+				enumName, err := t.buildRefEnum(ref, schemas)
+				if err != nil {
+					return fmt.Errorf("error building enum for ref %q: %w", *potentialRef, err)
+				}
+
+				description, _ := getOrderedMapKey[string](property, "description")
+				if description == nil || *description == "" {
+					description = &ref.schema.Description
+				}
+
+				field := Field{
+					Name:        key,
+					Description: *description,
+					Type:        enumName,
+					Required:    contains(key, requiredFields) && !wrapped,
+					ReadOnly:    *readOnly,
+					WriteOnly:   *writeOnly,
+				}
+				deprecate(&field, deprecated, reason)
+				parent.Fields = append(parent.Fields, field)
+				continue
+			}
+
+			t.path = append(t.path, key)
+			err = t.walkRef(ref, key, parent, schemas)
+			t.path = t.path[:len(t.path)-1]
+			if err != nil {
+				return fmt.Errorf("error processing ref at %q: %w", key, err)
+			}
+
+			parent.Fields[len(parent.Fields)-1].ReadOnly = *readOnly
+			parent.Fields[len(parent.Fields)-1].WriteOnly = *writeOnly
+			deprecate(&parent.Fields[len(parent.Fields)-1], deprecated, reason)
+
 			parent.Fields = append(parent.Fields, schema.Fields...)
-			return nil
+			continue
 		}
 
 		// Any of the below getOrderedMapKey calls that omit an error check is due to those fields not being required
 		// for the purposes of running this program.
-		required, _ := getOrderedMapKey[[]string](property, "required")
+		required := requiredKeys(property)
 
 		description, _ := getOrderedMapKey[string](property, "description")
 		if description == nil {
@@ -169,36 +473,150 @@ func walkObject(root *orderedmap.OrderedMap, parent *Schema, schemas *[]Schema,
 			description = &blank
 		}
 
-		fieldType, err := getOrderedMapKey[string](property, "type")
+		types, nullable, err := getTypes(property)
 		if err != nil {
 			return fmt.Errorf("error on field %q getting object field type: %w", key, err)
 		}
+		nullable = nullable || wrapped
 
-		// Declare the field early and let any further traversal operations update the field if needed.
+		// A "null" in the list of types means the field can never be non-null, even when listed as required.
 		field := Field{
 			Name:        key,
 			Description: *description,
-			Type:        *fieldType,
-			Required:    contains(key, requiredFields),
-			Array:       isArray(*fieldType),
+			Required:    contains(key, requiredFields) && !nullable,
+			ReadOnly:    *readOnly,
+			WriteOnly:   *writeOnly,
+		}
+		deprecate(&field, deprecated, reason)
+
+		if len(types) > 1 {
+			unionName, err := t.multiTypeUnion(key, types, schemas)
+			if err != nil {
+				return fmt.Errorf("error on field %q: %w", key, err)
+			}
+
+			field.Type = unionName
+			parent.Fields = append(parent.Fields, field)
+			continue
+		}
+
+		var fieldType string
+		if len(types) == 1 {
+			fieldType = types[0]
+		}
+
+		// Declare the field early and let any further traversal operations update the field if needed.
+		field.Type = fieldType
+		field.Array = isArray(fieldType)
+
+		enumValues, err := getOrderedMapKey[[]any](property, "enum")
+		if err != nil {
+			return fmt.Errorf("error on field %q getting enum values: %w", key, err)
+		}
+
+		if len(*enumValues) > 0 {
+			deprecatedValues, err := deprecatedEnumValues(property)
+			if err != nil {
+				return fmt.Errorf("error on field %q: %w", key, err)
+			}
+
+			valueDescriptions, err := enumValueDescriptions(property)
+			if err != nil {
+				return fmt.Errorf("error on field %q: %w", key, err)
+			}
+
+			enum, err := t.buildEnum(key, "", *enumValues, deprecatedValues, valueDescriptions)
+			if err != nil {
+				return fmt.Errorf("error building enum for field %q: %w", key, err)
+			}
+
+			field.Type, err = t.addSchema(schemas, enum)
+			if err != nil {
+				return fmt.Errorf("error adding enum for field %q: %w", key, err)
+			}
+
+			parent.Fields = append(parent.Fields, field)
+			continue
 		}
 
-		switch *fieldType {
+		if t.opts.UnionMode == UnionsAsTypes {
+			union, ok, err := t.propertyUnion(key, property, schemas, doc)
+			if err != nil {
+				return fmt.Errorf("error building union for field %q: %w", key, err)
+			}
+
+			if ok {
+				field.Type, err = t.addSchema(schemas, union)
+				if err != nil {
+					return fmt.Errorf("error adding union for field %q: %w", key, err)
+				}
+
+				parent.Fields = append(parent.Fields, field)
+				continue
+			}
+
+			// Without a type of its own, a property whose branches can't make up a union can hold any of them.
+			if fieldType == "" && hasComposition(property) {
+				field.Type, err = t.mapScalar(schemas)
+				if err != nil {
+					return fmt.Errorf("error on field %q: %w", key, err)
+				}
+
+				parent.Fields = append(parent.Fields, field)
+				continue
+			}
+		}
+
+		switch fieldType {
 		case typeObject:
+			if isFreeForm(property) {
+				field.Type, field.Array, err = t.mapType(key, property, schemas, doc)
+				if err != nil {
+					return fmt.Errorf("error on free-form object %q: %w", key, err)
+				}
+				break
+			}
+
 			schema.TypeName = key
 
-			if err := walk(property, *required, &schema, schemas, typeObject, definitions, schemaPath); err != nil {
+			t.path = append(t.path, key)
+			err := t.walk(property, required, &schema, schemas, typeObject, doc)
+			t.path = t.path[:len(t.path)-1]
+			if err != nil {
 				return fmt.Errorf("error walking down nested object %q: %w", key, err)
 			}
 
-			*schemas = append(*schemas, schema)
+			field.Type, err = t.addSchema(schemas, schema)
+			if err != nil {
+				return fmt.Errorf("error adding nested object %q: %w", key, err)
+			}
 		case typeArray:
-			if err := walk(property, *required, &schema, schemas, typeArray, definitions, schemaPath); err != nil {
+			t.path = append(t.path, key)
+			err := t.walkList(&field, key, "", property, schemas, doc)
+			t.path = t.path[:len(t.path)-1]
+			if err != nil {
 				return fmt.Errorf("error walking down array %q: %w", key, err)
 			}
 
-			if schema.Fields != nil {
-				field.Type = schema.Fields[0].Type
+			if field.Array && !field.Required {
+				field.Required, err = t.listRequired(property, nullable)
+				if err != nil {
+					return fmt.Errorf("error on array %q: %w", key, err)
+				}
+			}
+		default:
+			field.Type, err = t.scalarType(key, property, fieldType, schemas)
+			if err != nil {
+				return fmt.Errorf("error on field %q: %w", key, err)
+			}
+
+			// Properties without a type or a mapped format, such as the ones reverse writes for custom scalars
+			// without a format, accept anything.
+			if field.Type == "" {
+				field.Type, err = t.mapScalar(schemas)
+				if err != nil {
+					return fmt.Errorf("error on field %q: %w", key, err)
+				}
 			}
 		}
 
@@ -206,93 +624,318 @@ func walkObject(root *orderedmap.OrderedMap, parent *Schema, schemas *[]Schema,
 
 	}
 
-	return nil
+	if err := t.withDefaults(root, parent.Fields[first:]); err != nil {
+		return err
+	}
+
+	return t.withConstraints(root, parent.Fields[first:])
 }
 
-func walkArray(root *orderedmap.OrderedMap, parent *Schema, schemas *[]Schema, definitions jsonschema.Definitions, schemaPath string) error {
-	// .Keys() will contain the list of fields from an items declaration.
-	for _, key := range root.Keys() {
-		raw, ok := root.Get(key)
-		if !ok {
-			return fmt.Errorf("key value not found")
+func (t *transformer) walkArray(root *orderedmap.OrderedMap, parent *Schema, schemas *[]Schema, doc *document) error {
+	// Enum items are named after the array, and don't need any further walking.
+	enumValues, err := getOrderedMapKey[[]any](root, "enum")
+	if err != nil {
+		return fmt.Errorf("error getting enum values of array items: %w", err)
+	}
+
+	if len(*enumValues) > 0 {
+		deprecatedValues, err := deprecatedEnumValues(root)
+		if err != nil {
+			return fmt.Errorf("error on array items: %w", err)
 		}
-		switch key {
-		case "type":
 
-			fieldType := raw.(string)
+		valueDescriptions, err := enumValueDescriptions(root)
+		if err != nil {
+			return fmt.Errorf("error on array items: %w", err)
+		}
 
-			if fieldType == typeObject {
-				// TODO: re-use code from here and the default case.
-				newSchema := Schema{
-					TypeName:    parent.TypeName,
-					Description: parent.Description,
-					Fields:      []Field{},
-				}
+		enum, err := t.buildEnum(parent.TypeName, "", *enumValues, deprecatedValues, valueDescriptions)
+		if err != nil {
+			return fmt.Errorf("error building enum for array items: %w", err)
+		}
 
-				if err := walk(root, []string{}, &newSchema, schemas, typeObject, definitions, schemaPath); err != nil {
-					return fmt.Errorf("error walking down object array item %q: %w", key, err)
-				}
+		enumName, err := t.addSchema(schemas, enum)
+		if err != nil {
+			return fmt.Errorf("error adding enum for array items: %w", err)
+		}
+
+		parent.Fields = append(parent.Fields, Field{Type: enumName})
+		return nil
+	}
+
+	potentialRef, err := getOrderedMapKey[string](root, "$ref")
+	if err != nil {
+		return fmt.Errorf("error getting array items ref: %w", err)
+	}
+
+	if *potentialRef != "" {
+		return t.walkArrayRef(*potentialRef, parent, schemas, doc)
+	}
 
-				// Merge newSchema back into the parent.
-				parent.Fields = append(parent.Fields, newSchema.Fields...)
-				parent.Fields[0].Type = typeObject
-				*schemas = append(*schemas, newSchema)
+	types, _, err := getTypes(root)
+	if err != nil {
+		return fmt.Errorf("error getting array items type: %w", err)
+	}
 
-				return nil
+	// Items without a type are objects when they have properties, and accept anything when they only have annotation
+	// or validation keywords. Otherwise, they wrap a single object schema under any key, e.g.
+	// {"item": {"type": "object", ...}}.
+	if len(types) == 0 {
+		var keys []string
+		for _, key := range root.Keys() {
+			if !isItemsKeyword(key) {
+				keys = append(keys, key)
 			}
+		}
 
-			// Depending on the type, the casing can change (mainly with objects), so some extra formatting is needed.
-			field := Field{
-				Type: fieldType,
+		if _, ok := root.Get("properties"); ok {
+			types = []string{typeObject}
+		} else if len(keys) == 0 {
+			return nil
+		} else if len(keys) > 1 {
+			return fmt.Errorf("array items without a type, properties or a $ref must wrap a single object, got %v", keys)
+		} else {
+			wrapped, _ := root.Get(keys[0])
+			item, ok := wrapped.(orderedmap.OrderedMap)
+			if !ok {
+				return fmt.Errorf("array items %q are not an object", keys[0])
 			}
 
-			parent.Fields = append(parent.Fields, field)
-		case "$ref":
-			potentialRef, _ := getOrderedMapKey[string](root, "$ref")
-			if potentialRef != nil && *potentialRef != "" {
-				// TODO: remove hard-coded $defs
-				ref, err := getRef(*potentialRef, "$defs", schemaPath, definitions)
-				if err != nil {
-					return fmt.Errorf("error getting ref with path %q: %w", *potentialRef, err)
-				}
+			if _, ok := item.Get("properties"); !ok {
+				return fmt.Errorf("array items %q have no properties", keys[0])
+			}
+			root, types = &item, []string{typeObject}
+		}
+	}
 
-				newSchema := Schema{
-					TypeName:    parent.TypeName,
-					Description: parent.Description,
-					Fields:      []Field{},
-				}
+	if len(types) != 1 {
+		return fmt.Errorf("array items must have exactly one non-null type, got %v", types)
+	}
+	fieldType := types[0]
 
-				if err := walkRef(ref, &newSchema, schemas, schemaPath); err != nil {
-					return fmt.Errorf("error processing ref at %q", key)
-				}
+	switch fieldType {
+	case typeObject:
+		if isFreeForm(root) {
+			mapType, array, err := t.mapType(parent.TypeName, root, schemas, doc)
+			if err != nil {
+				return fmt.Errorf("error on free-form array items: %w", err)
+			}
+
+			parent.Fields = append(parent.Fields, Field{Type: mapType, Array: array})
+			return nil
+		}
+
+		newSchema := Schema{
+			TypeName:    parent.TypeName,
+			Description: parent.Description,
+			Fields:      []Field{},
+		}
+
+		if err := t.walk(root, requiredKeys(root), &newSchema, schemas, typeObject, doc); err != nil {
+			return fmt.Errorf("error walking down object array items: %w", err)
+		}
+
+		// The parent only needs to know the name of the item type.
+		itemName, err := t.addSchema(schemas, newSchema)
+		if err != nil {
+			return fmt.Errorf("error adding object array items: %w", err)
+		}
+
+		parent.Fields = append(parent.Fields, Field{Type: itemName})
+	case typeArray:
+		// Nested arrays are walked down until their innermost items, and wrapped in one more list.
+		item := Field{}
+		if err := t.walkList(&item, parent.TypeName, parent.Description, root, schemas, doc); err != nil {
+			return fmt.Errorf("error walking down nested array items: %w", err)
+		}
+
+		parent.Fields = append(parent.Fields, item)
+	default:
+		scalar, err := t.scalarType(parent.TypeName, root, fieldType, schemas)
+		if err != nil {
+			return fmt.Errorf("error on array items: %w", err)
+		}
+
+		parent.Fields = append(parent.Fields, Field{Type: scalar})
+	}
 
-				parent.Fields = append(parent.Fields, newSchema.Fields...)
-				return nil
+	return nil
+}
+
+// itemsKeywords lists the annotation and validation keywords array items can have besides the ones describing their
+// type. Annotations have no place in a list type, and validation keywords apply to each item rather than to the field,
+// so they are dropped instead of being turned into constraints.
+var itemsKeywords = map[string]bool{
+	"title":       true,
+	"description": true,
+	"$comment":    true,
+	"examples":    true,
+	"default":     true,
+	"deprecated":  true,
+	"readOnly":    true,
+	"writeOnly":   true,
+	"const":       true,
+	"format":      true,
+}
+
+// isItemsKeyword reports whether key is an annotation or validation keyword of array items, see itemsKeywords.
+func isItemsKeyword(key string) bool {
+	if itemsKeywords[key] {
+		return true
+	}
+
+	for _, keyword := range constraintKeywords {
+		if keyword.name == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// walkArrayRef adds the field for array items referring to another schema through $ref to parent.
+func (t *transformer) walkArrayRef(potentialRef string, parent *Schema, schemas *[]Schema, doc *document) error {
+	if typeName, ok := t.opts.TypeMapping.Refs[potentialRef]; ok {
+		parent.Fields = append(parent.Fields, Field{Type: t.mappedType(typeName)})
+		return nil
+	}
+
+	ref, err := t.getRef(potentialRef, doc)
+	if err != nil {
+		return fmt.Errorf("error getting ref with path %q: %w", potentialRef, err)
+	}
+
+	if len(ref.schema.Enum) > 0 {
+		enumName, err := t.buildRefEnum(ref, schemas)
+		if err != nil {
+			return fmt.Errorf("error building enum for ref %q: %w", potentialRef, err)
+		}
+
+		parent.Fields = append(parent.Fields, Field{Type: enumName})
+		return nil
+	}
+
+	newSchema := Schema{
+		TypeName:    parent.TypeName,
+		Description: parent.Description,
+		Fields:      []Field{},
+	}
+
+	if err := t.walkRef(ref, "", &newSchema, schemas); err != nil {
+		return fmt.Errorf("error processing ref %q: %w", potentialRef, err)
+	}
+
+	parent.Fields = append(parent.Fields, newSchema.Fields...)
+	return nil
+}
+
+// getTypes reads the "type" keyword of a property, which is either a single type name or an array of type names.
+// A "null" entry only marks the property as nullable, so it is reported through the returned bool instead of as a type.
+func getTypes(property any) ([]string, bool, error) {
+	raw, err := getOrderedMapKey[any](property, "type")
+	if err != nil {
+		return nil, false, err
+	}
+
+	switch value := (*raw).(type) {
+	case nil:
+		return nil, false, nil
+	case string:
+		if value == typeNull {
+			return nil, false, fmt.Errorf("a type of only %q is not supported", typeNull)
+		}
+		return []string{value}, false, nil
+	case []any:
+		var types []string
+		var nullable bool
+		for _, elem := range value {
+			typeName, ok := elem.(string)
+			if !ok {
+				return nil, false, fmt.Errorf("the type %v is not a string", elem)
 			}
 
-		default:
-			// This key could be an object, so entertain that first before erroring on an unknown key.
-			properties, err := extractLeaf(raw, "properties")
-			if err != nil {
-				return fmt.Errorf("unknown key in array items: %q", key)
+			if typeName == typeNull {
+				nullable = true
+				continue
 			}
-			newSchema := Schema{
-				TypeName:    parent.TypeName,
-				Description: parent.Description,
-				Fields:      []Field{},
+			types = append(types, typeName)
+		}
+
+		if len(types) == 0 {
+			return nil, false, fmt.Errorf("a type of only %q is not supported", typeNull)
+		}
+		return types, nullable, nil
+	default:
+		return nil, false, fmt.Errorf("the value %v is neither a string nor an array of strings", value)
+	}
+}
+
+// requiredKeys reads the "required" keyword of a raw schema, which is decoded as a list of any values rather than
+// strings. Values that aren't strings are skipped.
+func requiredKeys(schema any) []string {
+	raw, _ := getOrderedMapKey[[]any](schema, "required")
+
+	required := make([]string, 0, len(*raw))
+	for _, value := range *raw {
+		if key, ok := value.(string); ok {
+			required = append(required, key)
+		}
+	}
+
+	return required
+}
+
+// nullableBranch reads a schema written as an anyOf, or a oneOf, of a single schema and {"type": "null"}, the way a
+// nullable $ref is written since "null" can't be added to its type. It returns that schema with the keywords next to
+// the composition, such as a description, merged on top, and whether property was written that way.
+func nullableBranch(property any) (*orderedmap.OrderedMap, bool) {
+	outer, ok := property.(orderedmap.OrderedMap)
+	if pointer, isPointer := property.(*orderedmap.OrderedMap); isPointer {
+		outer, ok = *pointer, true
+	}
+	if !ok {
+		return nil, false
+	}
+
+	for _, keyword := range []string{"anyOf", "oneOf"} {
+		branches, err := getOrderedMapKey[[]any](outer, keyword)
+		if err != nil || len(*branches) != 2 {
+			continue
+		}
+
+		var branch *orderedmap.OrderedMap
+		var nulls int
+		for _, elem := range *branches {
+			schema, ok := elem.(orderedmap.OrderedMap)
+			if !ok {
+				break
 			}
-			if err = walkObject(properties, &newSchema, schemas, []string{}, definitions, schemaPath); err != nil {
-				return fmt.Errorf("error walking down object array item %q: %w", key, err)
+
+			if typeName, _ := getOrderedMapKey[any](schema, "type"); *typeName == typeNull {
+				nulls++
+				continue
 			}
+			branch = &schema
+		}
+		if nulls != 1 || branch == nil {
+			continue
+		}
 
-			// Merge newSchema back into the parent.
-			parent.Fields = append(parent.Fields, newSchema.Fields...)
-			parent.Fields[0].Type = typeObject
-			*schemas = append(*schemas, newSchema)
+		merged := orderedmap.New()
+		for _, key := range branch.Keys() {
+			value, _ := branch.Get(key)
+			merged.Set(key, value)
+		}
+		for _, key := range outer.Keys() {
+			if key != keyword {
+				value, _ := outer.Get(key)
+				merged.Set(key, value)
+			}
 		}
+		return merged, true
 	}
 
-	return nil
+	return nil, false
 }
 
 func extractLeaf(node any, key string) (*orderedmap.OrderedMap, error) {
@@ -329,6 +972,77 @@ func getOrderedMapKey[T any](property any, key string) (*T, error) {
 	return &assertion, nil
 }
 
+// walkList walks down an array schema named typeName and turns field into a list of its items, or into the object
+// type a tuple is turned into.
+func (t *transformer) walkList(field *Field, typeName, description string, property any, schemas *[]Schema, doc *document) error {
+	positions, err := tupleItems(property)
+	if err != nil {
+		return err
+	}
+
+	if len(positions) > 0 {
+		return t.walkTuple(field, typeName, positions, schemas, doc)
+	}
+
+	schema := Schema{TypeName: typeName, Description: description, Fields: []Field{}}
+	if err := t.walk(property, []string{}, &schema, schemas, typeArray, doc); err != nil {
+		return err
+	}
+
+	item, err := t.arrayItem(schema.Fields, schemas)
+	if err != nil {
+		return err
+	}
+
+	wrapItem(field, item)
+	return nil
+}
+
+// arrayItem returns the item field walkArray added to fields. Arrays without items accept anything, so their items
+// are of the map scalar.
+func (t *transformer) arrayItem(fields []Field, schemas *[]Schema) (Field, error) {
+	if len(fields) > 0 {
+		return fields[0], nil
+	}
+
+	scalar, err := t.mapScalar(schemas)
+	return Field{Type: scalar}, err
+}
+
+// wrapItem turns field into a list of item, the field built by walkArray for the items of an array. The item can itself
+// be a list, in which case the field becomes a nested list.
+func wrapItem(field *Field, item Field) {
+	field.Type = item.Type
+	field.Array = true
+	field.ListDepth = 0
+	field.ItemsRequired = nil
+
+	if depth := item.listDepth(); depth > 0 {
+		field.ListDepth = depth + 1
+	}
+
+	itemsRequired := append([]bool{item.Required}, item.ItemsRequired...)
+	for _, required := range itemsRequired {
+		if required {
+			field.ItemsRequired = itemsRequired
+			break
+		}
+	}
+}
+
+// listDepth returns the number of lists wrapping the type of the field.
+func (f Field) listDepth() int {
+	if !f.Array {
+		return 0
+	}
+
+	if f.ListDepth > 1 {
+		return f.ListDepth
+	}
+
+	return 1
+}
+
 func isArray(typeName string) bool {
 	return typeName == "array"
 }
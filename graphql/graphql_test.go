@@ -5,6 +5,7 @@ import (
 	"jgschema/jsonutils"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -13,6 +14,7 @@ func TestTransform(t *testing.T) {
 	type test struct {
 		description string
 		inputSchema string // path to test file
+		options     Options
 		wantGraphQL []Schema
 		wantErr     error
 	}
@@ -100,7 +102,7 @@ func TestTransform(t *testing.T) {
 						},
 						{
 							Name:        "sampleObjectField",
-							Type:        "object",
+							Type:        "sampleObjectField",
 							Description: "Sample object field description.",
 						},
 					},
@@ -118,6 +120,34 @@ func TestTransform(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			description: "should process a JSON schema with required fields in nested objects.",
+			inputSchema: fmt.Sprintf("%s/nested-required-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "nestedRequiredSchema",
+					Description: "A schema with required fields in nested objects.",
+					Fields: []Field{
+						{Name: "customer", Type: "customer"},
+						{Name: "lines", Type: "lines", Array: true},
+					},
+				},
+				{
+					TypeName: "customer",
+					Fields: []Field{
+						{Name: "name", Type: "string", Required: true},
+						{Name: "email", Type: "string"},
+					},
+				},
+				{
+					TypeName: "lines",
+					Fields: []Field{
+						{Name: "sku", Type: "string", Required: true},
+						{Name: "discount", Type: "number"},
+					},
+				},
+			},
+		},
 		{
 			description: "should process a JSON schema with a nested object using a definitions ref.",
 			inputSchema: fmt.Sprintf("%s/def-schema.json", schemaTestDir),
@@ -132,8 +162,8 @@ func TestTransform(t *testing.T) {
 							Description: "Sample field description.",
 						},
 						{
-							Name:        "sampleObject",
-							Type:        "object",
+							Name:        "sampleObjectField",
+							Type:        "sampleObject",
 							Description: "Sample object field description.",
 						},
 					},
@@ -166,8 +196,8 @@ func TestTransform(t *testing.T) {
 							Description: "Sample field description.",
 						},
 						{
-							Name:        "simpleSchema",
-							Type:        "object",
+							Name:        "sampleObjectField",
+							Type:        "simpleSchema",
 							Description: "A sample schema for the purpose of testing.",
 						},
 					},
@@ -205,6 +235,110 @@ func TestTransform(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			description: "should process a JSON schema with deprecated fields and enum values.",
+			inputSchema: fmt.Sprintf("%s/deprecated-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "deprecatedSchema",
+					Description: "A schema with deprecated fields and enum values.",
+					Fields: []Field{
+						{
+							Name:              "fullName",
+							Type:              "string",
+							Description:       "Use firstName and lastName instead.",
+							Deprecated:        true,
+							DeprecationReason: "Use firstName and lastName instead.",
+						},
+						{
+							Name:              "legacyId",
+							Type:              "integer",
+							Description:       "Identifier from the previous system.",
+							Deprecated:        true,
+							DeprecationReason: "Use id instead.",
+						},
+						{
+							Name: "status",
+							Type: "status",
+						},
+						{
+							Name:              "owner",
+							Type:              "owner",
+							Description:       "Owners are being replaced by teams.",
+							Deprecated:        true,
+							DeprecationReason: "Owners are being replaced by teams.",
+						},
+					},
+				},
+				{
+					TypeName: "status",
+					Kind:     KindEnum,
+					Values: []EnumValue{
+						{Name: "WAITING"},
+						{Name: "PENDING", Deprecated: true, DeprecationReason: "Use WAITING instead."},
+						{Name: "DONE"},
+					},
+				},
+				{
+					TypeName:    "owner",
+					Description: "Owners are being replaced by teams.",
+					Fields:      []Field{{Name: "name", Type: "string"}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should process a JSON schema with arrays of arrays.",
+			inputSchema: fmt.Sprintf("%s/nested-array-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "nestedArraySchema",
+					Description: "A schema with arrays of arrays.",
+					Fields: []Field{
+						{
+							Name:        "matrix",
+							Type:        "number",
+							Description: "Rows of numbers.",
+							Array:       true,
+							ListDepth:   2,
+						},
+						{
+							Name:      "cubes",
+							Type:      "cubes",
+							Array:     true,
+							ListDepth: 3,
+						},
+						{
+							Name:        "grid",
+							Type:        "number",
+							Description: "Items listing items before their type.",
+							Array:       true,
+							ListDepth:   2,
+						},
+						{
+							Name:      "boxes",
+							Type:      "boxes",
+							Array:     true,
+							ListDepth: 2,
+						},
+					},
+				},
+				{
+					TypeName: "cubes",
+					Fields:   []Field{{Name: "side", Type: "integer"}},
+				},
+				{
+					TypeName: "boxes",
+					Fields:   []Field{{Name: "depth", Type: "integer"}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should fail on array items wrapping an object without properties.",
+			inputSchema: fmt.Sprintf("%s/array-items-error-schema.json", schemaTestDir),
+			wantErr:     fmt.Errorf(`error when walking down the properties tree: error walking down array "points": array items "point" have no properties`),
+		},
 		{
 			description: "should process a JSON schema with an array of objects.",
 			inputSchema: fmt.Sprintf("%s/object-array-schema.json", schemaTestDir),
@@ -215,13 +349,13 @@ func TestTransform(t *testing.T) {
 					Fields: []Field{
 						{
 							Name:        "arrayObjectField",
-							Type:        "object",
+							Type:        "arrayObjectField",
 							Description: "Sample array field description.",
 							Array:       true,
 						},
 						{
 							Name:        "secondArrayField",
-							Type:        "object",
+							Type:        "secondArrayField",
 							Description: "Sample array field description.",
 							Array:       true,
 						},
@@ -265,12 +399,12 @@ func TestTransform(t *testing.T) {
 						},
 						{
 							Name:        "sampleObjectField",
-							Type:        "object",
+							Type:        "sampleObjectField",
 							Description: "Sample object field description.",
 						},
 						{
 							Name:        "simpleSchema",
-							Type:        "object",
+							Type:        "simpleSchema",
 							Description: "A sample schema for the purpose of testing.",
 						},
 					},
@@ -314,12 +448,12 @@ func TestTransform(t *testing.T) {
 						},
 						{
 							Name:        "sampleObjectField",
-							Type:        "object",
+							Type:        "sampleObjectField",
 							Description: "Sample object field description.",
 						},
 						{
 							Name:        "simpleSchema",
-							Type:        "object",
+							Type:        "simpleSchema",
 							Description: "A sample schema for the purpose of testing.",
 						},
 					},
@@ -348,31 +482,864 @@ func TestTransform(t *testing.T) {
 			},
 			wantErr: nil,
 		},
-	}
-
-	for _, test := range tests {
-		t.Run(test.description, func(t *testing.T) {
-			abs, _ := filepath.Abs(test.inputSchema)
-			jsonSchema, err := jsonutils.ReadSchema(abs)
-			if err != nil {
-				t.Fatalf("error reading JSON schema test file at path %q: %v", test.inputSchema, err)
-			}
-
-			schemas, err := transform(jsonSchema, abs, jsonSchema.Title)
-			// TODO; look at some cleaner error testing.
-			if err == nil && test.wantErr != nil {
-				t.Errorf("expected the following error, but did not get any error: %v", test.wantErr)
-			} else if err != nil {
-				if test.wantErr == nil {
-					t.Errorf("got the following error when one wasn't expected: %v", err)
-				} else if test.wantErr.Error() != err.Error() {
-					t.Errorf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
-				}
-			}
-
-			if !reflect.DeepEqual(test.wantGraphQL, schemas) {
-				t.Errorf("did not get expected schemas.\nwant - %#v\ngot - %#v", test.wantGraphQL, schemas)
-			}
-		})
-	}
+		{
+			description: "should process a JSON schema with inline, array item and definition enums.",
+			inputSchema: fmt.Sprintf("%s/enum-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "enumSchema",
+					Description: "A schema with enum fields.",
+					Fields: []Field{
+						{
+							Name:        "status",
+							Type:        "status",
+							Description: "Status of the account.",
+							Required:    true,
+						},
+						{
+							Name:        "tier",
+							Type:        "tier",
+							Description: "Pricing tier of the account.",
+						},
+						{
+							Name:        "tags",
+							Type:        "tags",
+							Description: "Tags attached to the account.",
+							Array:       true,
+						},
+					},
+				},
+				{
+					TypeName: "status",
+					Kind:     KindEnum,
+					Values:   []EnumValue{{Name: "ACTIVE"}, {Name: "DISABLED"}},
+				},
+				{
+					TypeName:    "tier",
+					Description: "Pricing tier of the account.",
+					Kind:        KindEnum,
+					Values:      []EnumValue{{Name: "FREE"}, {Name: "PRO"}},
+				},
+				{
+					TypeName: "tags",
+					Kind:     KindEnum,
+					Values:   []EnumValue{{Name: "new"}, {Name: "long_standing"}, {Name: "_2fa"}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should turn a oneOf property into a union when unions are enabled.",
+			inputSchema: fmt.Sprintf("%s/union-schema.json", schemaTestDir),
+			options:     Options{UnionMode: UnionsAsTypes},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "unionSchema",
+					Description: "A schema with a oneOf property.",
+					Fields: []Field{
+						{
+							Name:        "payment",
+							Type:        "payment",
+							Description: "How the order is paid.",
+						},
+					},
+				},
+				{
+					TypeName:    "card",
+					Description: "A credit card.",
+					Fields: []Field{
+						{
+							Name:        "number",
+							Type:        "string",
+							Description: "Card number.",
+						},
+					},
+				},
+				{
+					TypeName: "bankTransfer",
+					Fields: []Field{
+						{
+							Name:        "iban",
+							Type:        "string",
+							Description: "Account to transfer from.",
+						},
+					},
+				},
+				{
+					TypeName: "payment",
+					Kind:     KindUnion,
+					Types:    []string{"card", "bankTransfer"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should keep oneOf and anyOf properties with non-object branches out of unions.",
+			inputSchema: fmt.Sprintf("%s/union-scalar-schema.json", schemaTestDir),
+			options:     Options{UnionMode: UnionsAsTypes},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "unionScalarSchema",
+					Description: "A schema with oneOf properties that can't be unions.",
+					Fields: []Field{
+						{Name: "id", Type: "JSON"},
+						{Name: "owner", Type: "JSON", Description: "A user name or the full user."},
+						{Name: "email", Type: "string"},
+					},
+				},
+				{TypeName: "JSON", Kind: KindScalar},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should turn a root schema made of anyOf into a union when unions are enabled.",
+			inputSchema: fmt.Sprintf("%s/union-root-schema.json", schemaTestDir),
+			options:     Options{UnionMode: UnionsAsTypes},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "shape",
+					Description: "One of the supported shapes.",
+					Kind:        KindUnion,
+					Types:       []string{"circle", "square"},
+				},
+				{
+					TypeName: "circle",
+					Fields: []Field{
+						{
+							Name: "radius",
+							Type: "number",
+						},
+					},
+				},
+				{
+					TypeName: "square",
+					Fields: []Field{
+						{
+							Name: "side",
+							Type: "number",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should add a oneOf union as a field of a root schema with properties.",
+			inputSchema: fmt.Sprintf("%s/schema-with-oneOf.json", schemaTestDir),
+			options:     Options{UnionMode: UnionsAsTypes},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "oneOfSchema",
+					Description: "A schema with a oneOf ref.",
+					Fields: []Field{
+						{
+							Name:        "exampleField",
+							Type:        "string",
+							Description: "Example field description.",
+						},
+						{
+							Name:        "sampleObjectField",
+							Type:        "sampleObjectField",
+							Description: "Sample object field description.",
+						},
+						{
+							Name: "oneOf",
+							Type: "oneOfSchemaOneOf",
+						},
+					},
+				},
+				{
+					TypeName: "sampleObjectField",
+					Fields: []Field{
+						{
+							Name:        "nestedField",
+							Type:        "integer",
+							Description: "Nested object field description.",
+						},
+					},
+				},
+				{
+					TypeName:    "simpleSchema",
+					Description: "A sample schema for the purpose of testing.",
+					Fields: []Field{
+						{
+							Name:        "sampleField",
+							Type:        "string",
+							Description: "Sample field description.",
+						},
+					},
+				},
+				{
+					TypeName: "oneOfSchemaOneOf",
+					Kind:     KindUnion,
+					Types:    []string{"simpleSchema"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should treat a null entry in a type array as making the field nullable.",
+			inputSchema: fmt.Sprintf("%s/nullable-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "nullableSchema",
+					Description: "A schema with type arrays.",
+					Fields: []Field{
+						{
+							Name:        "nickname",
+							Type:        "string",
+							Description: "Optional nickname.",
+						},
+						{
+							Name:        "age",
+							Type:        "integer",
+							Description: "Age in years.",
+							Required:    true,
+						},
+						{
+							Name:        "aliases",
+							Type:        "string",
+							Description: "Other names.",
+							Array:       true,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should read an anyOf or oneOf of a schema and a null schema as a nullable schema.",
+			inputSchema: fmt.Sprintf("%s/nullable-ref-schema.json", schemaTestDir),
+			options:     Options{ItemNullability: ItemsFromSchema},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "nullableRefSchema",
+					Description: "A schema with nullable references.",
+					Fields: []Field{
+						{Name: "status", Type: "status", Description: "Where the order is at."},
+						{Name: "customer", Type: "customer"},
+						{Name: "lines", Type: "status", Array: true, Required: true},
+						{Name: "codes", Type: "string", Array: true, Required: true, ItemsRequired: []bool{true}},
+					},
+				},
+				{
+					TypeName: "status",
+					Kind:     KindEnum,
+					Values:   []EnumValue{{Name: "OPEN"}, {Name: "CLOSED", Description: "Paid and shipped."}},
+				},
+				{TypeName: "customer", Fields: []Field{{Name: "name", Type: "string"}}},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should fail on a field with several non-null types by default.",
+			inputSchema: fmt.Sprintf("%s/multi-type-schema.json", schemaTestDir),
+			wantErr:     fmt.Errorf(`error when walking down the properties tree: error on field "identifier": multiple types [string integer] are not supported, either use a single type or enable the multi-type union strategy`),
+		},
+		{
+			description: "should turn a field with several non-null types into a union of wrapper types.",
+			inputSchema: fmt.Sprintf("%s/multi-type-schema.json", schemaTestDir),
+			options:     Options{MultiTypeStrategy: MultiTypeUnion},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "multiTypeSchema",
+					Description: "A schema with a field allowing several types.",
+					Fields: []Field{
+						{
+							Name:        "identifier",
+							Type:        "identifier",
+							Description: "Either a name or a number.",
+						},
+					},
+				},
+				{
+					TypeName: "identifierString",
+					Fields:   []Field{{Name: "value", Type: "string", Required: true}},
+				},
+				{
+					TypeName: "identifierInteger",
+					Fields:   []Field{{Name: "value", Type: "integer", Required: true}},
+				},
+				{
+					TypeName: "identifier",
+					Kind:     KindUnion,
+					Types:    []string{"identifierString", "identifierInteger"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should process a JSON schema with refs to definitions in another file.",
+			inputSchema: fmt.Sprintf("%s/def-external-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "addressSchema",
+					Description: "A schema referring to definitions in another file.",
+					Fields: []Field{
+						{
+							Name:        "street",
+							Type:        "street",
+							Description: "A street address.",
+						},
+						{
+							Name:        "country",
+							Type:        "country",
+							Description: "A country.",
+						},
+					},
+				},
+				{
+					TypeName:    "street",
+					Description: "A street address.",
+					Fields: []Field{
+						{
+							Name:        "name",
+							Type:        "string",
+							Description: "Name of the street.",
+						},
+						{
+							Name:        "number",
+							Type:        "integer",
+							Description: "House number.",
+						},
+					},
+				},
+				{
+					TypeName:    "country",
+					Description: "A country.",
+					Fields: []Field{
+						{
+							Name:        "code",
+							Type:        "string",
+							Description: "ISO country code.",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should name the file and pointer of a missing definition in another file.",
+			inputSchema: fmt.Sprintf("%s/def-external-missing-schema.json", schemaTestDir),
+			wantErr:     fmt.Errorf(`error when walking down the properties tree: error getting ref with path "./external-defs.json#/$defs/city": error resolving pointer "/$defs/city" in %q: key "city" not found`, absTestPath(schemaTestDir, "external-defs.json")),
+		},
+		{
+			description: "should resolve refs using full JSON pointers.",
+			inputSchema: fmt.Sprintf("%s/pointer-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "pointerSchema",
+					Description: "A schema with refs using JSON pointers.",
+					Fields: []Field{
+						{
+							Name:        "inner",
+							Type:        "inner",
+							Description: "An object nested in a definition.",
+						},
+						{
+							Name:        "legacy",
+							Type:        "legacy",
+							Description: "A definition using the legacy keyword.",
+						},
+						{
+							Name:        "escaped",
+							Type:        "escaped",
+							Description: "A definition with escaped characters in its name.",
+						},
+						{
+							Name:        "untitled",
+							Type:        "lineItem",
+							Description: "An untitled definition named after a token that isn't a valid GraphQL name.",
+						},
+						{
+							Name:  "titled",
+							Type:  "MyItem",
+							Array: true,
+						},
+					},
+				},
+				{
+					TypeName:    "inner",
+					Description: "An object nested in a definition.",
+					Fields:      []Field{{Name: "value", Type: "string"}},
+				},
+				{
+					TypeName:    "legacy",
+					Description: "A definition using the legacy keyword.",
+					Fields:      []Field{{Name: "value", Type: "integer"}},
+				},
+				{
+					TypeName:    "escaped",
+					Description: "A definition with escaped characters in its name.",
+					Fields:      []Field{{Name: "value", Type: "boolean"}},
+				},
+				{
+					TypeName:    "lineItem",
+					Description: "An untitled definition named after a token that isn't a valid GraphQL name.",
+					Fields:      []Field{{Name: "value", Type: "number"}},
+				},
+				{
+					TypeName:    "MyItem",
+					Description: "A definition whose title isn't a valid GraphQL name.",
+					Fields:      []Field{{Name: "value", Type: "string"}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should emit a self-referencing definition once and refer to it by name.",
+			inputSchema: fmt.Sprintf("%s/recursive-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "tree",
+					Description: "A schema with a self-referencing definition.",
+					Fields: []Field{
+						{
+							Name:        "root",
+							Type:        "node",
+							Description: "A node of the tree.",
+						},
+					},
+				},
+				{
+					TypeName:    "node",
+					Description: "A node of the tree.",
+					Fields: []Field{
+						{
+							Name:        "value",
+							Type:        "string",
+							Description: "Value held by the node.",
+						},
+						{
+							Name:        "children",
+							Type:        "node",
+							Description: "Child nodes.",
+							Array:       true,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should turn the title of a referenced file into a valid type name.",
+			inputSchema: fmt.Sprintf("%s/titled-file-ref-schema.json", schemaTestDir),
+			options:     Options{RootNameSource: RootNameFromTitle},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "titledFileRefSchema",
+					Description: "A schema referring to a whole file with a title.",
+					Fields: []Field{
+						{
+							Name:        "basic",
+							Type:        "BasicSchema",
+							Description: "A file whose title isn't a valid GraphQL name.",
+						},
+					},
+				},
+				{
+					TypeName:    "BasicSchema",
+					Description: "A file whose title isn't a valid GraphQL name.",
+					Fields:      []Field{{Name: "name", Type: "string"}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should refer back to the root type from a mutually referencing file, naming the file after its name.",
+			inputSchema: fmt.Sprintf("%s/mutual-person.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "person",
+					Description: "A person, referring to a company in another file.",
+					Fields: []Field{
+						{
+							Name:        "name",
+							Type:        "string",
+							Description: "Full name.",
+						},
+						{
+							Name:        "employer",
+							Type:        "mutualCompany",
+							Description: "A company, referring back to people.",
+						},
+					},
+				},
+				{
+					TypeName:    "mutualCompany",
+					Description: "A company, referring back to people.",
+					Fields: []Field{
+						{
+							Name:        "employees",
+							Type:        "person",
+							Description: "People working for the company.",
+							Array:       true,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should add input types referring to each other when enabled for the run.",
+			inputSchema: fmt.Sprintf("%s/nested-schema.json", schemaTestDir),
+			options:     Options{InputTypes: true},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "nestedSchema",
+					Description: "A schema with a single nested object field.",
+					Fields: []Field{
+						{
+							Name:        "sampleField",
+							Type:        "string",
+							Description: "Sample field description.",
+						},
+						{
+							Name:        "sampleObjectField",
+							Type:        "sampleObjectField",
+							Description: "Sample object field description.",
+						},
+					},
+				},
+				{
+					TypeName: "sampleObjectField",
+					Fields: []Field{
+						{
+							Name:        "nestedField",
+							Type:        "integer",
+							Description: "Nested object field description.",
+						},
+					},
+				},
+				{
+					TypeName:    "nestedSchemaInput",
+					Description: "A schema with a single nested object field.",
+					Kind:        KindInput,
+					Fields: []Field{
+						{
+							Name:        "sampleField",
+							Type:        "string",
+							Description: "Sample field description.",
+						},
+						{
+							Name:        "sampleObjectField",
+							Type:        "sampleObjectFieldInput",
+							Description: "Sample object field description.",
+						},
+					},
+				},
+				{
+					TypeName: "sampleObjectFieldInput",
+					Kind:     KindInput,
+					Fields: []Field{
+						{
+							Name:        "nestedField",
+							Type:        "integer",
+							Description: "Nested object field description.",
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should add input types when the schema asks for them.",
+			inputSchema: fmt.Sprintf("%s/input-keyword-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "inputSchema",
+					Description: "A schema asking for input types.",
+					Fields: []Field{
+						{
+							Name:        "name",
+							Type:        "string",
+							Description: "Name of the item.",
+							Required:    true,
+						},
+						{
+							Name: "status",
+							Type: "status",
+						},
+						{
+							Name:        "dimensions",
+							Type:        "dimensions",
+							Description: "Size of the item.",
+						},
+					},
+				},
+				{
+					TypeName: "status",
+					Kind:     KindEnum,
+					Values:   []EnumValue{{Name: "NEW"}, {Name: "USED"}},
+				},
+				{
+					TypeName: "dimensions",
+					Fields:   []Field{{Name: "width", Type: "number"}},
+				},
+				{
+					TypeName:    "inputSchemaInput",
+					Description: "A schema asking for input types.",
+					Kind:        KindInput,
+					Fields: []Field{
+						{
+							Name:        "name",
+							Type:        "string",
+							Description: "Name of the item.",
+							Required:    true,
+						},
+						{
+							Name: "status",
+							Type: "status",
+						},
+						{
+							Name:        "dimensions",
+							Type:        "dimensionsInput",
+							Description: "Size of the item.",
+						},
+					},
+				},
+				{
+					TypeName: "dimensionsInput",
+					Kind:     KindInput,
+					Fields:   []Field{{Name: "width", Type: "number"}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should keep read and write only fields when there are no input types.",
+			inputSchema: fmt.Sprintf("%s/readwrite-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "account",
+					Description: "An account with server and client owned fields.",
+					Fields: []Field{
+						{
+							Name:        "id",
+							Type:        "string",
+							Description: "Assigned by the server.",
+							Required:    true,
+							ReadOnly:    true,
+						},
+						{
+							Name:        "password",
+							Type:        "string",
+							Description: "Only ever sent by the client.",
+							WriteOnly:   true,
+						},
+						{
+							Name:     "name",
+							Type:     "string",
+							Required: true,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should leave read only fields out of input types and write only fields out of object types.",
+			inputSchema: fmt.Sprintf("%s/readwrite-schema.json", schemaTestDir),
+			options:     Options{InputTypes: true},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "account",
+					Description: "An account with server and client owned fields.",
+					Fields: []Field{
+						{
+							Name:        "id",
+							Type:        "string",
+							Description: "Assigned by the server.",
+							Required:    true,
+							ReadOnly:    true,
+						},
+						{
+							Name:     "name",
+							Type:     "string",
+							Required: true,
+						},
+					},
+				},
+				{
+					TypeName:    "accountInput",
+					Description: "An account with server and client owned fields.",
+					Kind:        KindInput,
+					Fields: []Field{
+						{
+							Name:        "password",
+							Type:        "string",
+							Description: "Only ever sent by the client.",
+							WriteOnly:   true,
+						},
+						{
+							Name:     "name",
+							Type:     "string",
+							Required: true,
+						},
+					},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should not generate input types for objects made only of read only fields.",
+			inputSchema: fmt.Sprintf("%s/readonly-object-schema.json", schemaTestDir),
+			options:     Options{InputTypes: true},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "order",
+					Description: "An order with objects only the server sets.",
+					Fields: []Field{
+						{Name: "note", Type: "string"},
+						{Name: "audit", Type: "audit", Description: "Set by the server."},
+						{Name: "meta", Type: "meta", Description: "Only refers to a server-set object."},
+					},
+				},
+				{
+					TypeName: "audit",
+					Fields: []Field{
+						{Name: "createdAt", Type: "string", ReadOnly: true},
+						{Name: "createdBy", Type: "string", ReadOnly: true},
+					},
+				},
+				{
+					TypeName: "history",
+					Fields:   []Field{{Name: "version", Type: "integer", ReadOnly: true}},
+				},
+				{
+					TypeName: "meta",
+					Fields:   []Field{{Name: "history", Type: "history"}},
+				},
+				{
+					TypeName:    "orderInput",
+					Description: "An order with objects only the server sets.",
+					Kind:        KindInput,
+					Fields:      []Field{{Name: "note", Type: "string"}},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			description: "should remove objects made only of write only fields and leave unions out of input types.",
+			inputSchema: fmt.Sprintf("%s/writeonly-object-schema.json", schemaTestDir),
+			options:     Options{InputTypes: true, UnionMode: UnionsAsTypes},
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "signup",
+					Description: "A signup with an object only the client sends and a union.",
+					Fields: []Field{
+						{Name: "name", Type: "string"},
+						{Name: "plan", Type: "plan"},
+					},
+				},
+				{TypeName: "free", Fields: []Field{{Name: "until", Type: "string"}}},
+				{TypeName: "paid", Fields: []Field{{Name: "seats", Type: "integer"}}},
+				{TypeName: "plan", Kind: KindUnion, Types: []string{"free", "paid"}},
+				{
+					TypeName:    "signupInput",
+					Description: "A signup with an object only the client sends and a union.",
+					Kind:        KindInput,
+					Fields: []Field{
+						{Name: "name", Type: "string"},
+						{Name: "credentials", Type: "credentialsInput", Description: "Never sent back."},
+					},
+				},
+				{
+					TypeName: "credentialsInput",
+					Kind:     KindInput,
+					Fields:   []Field{{Name: "password", Type: "string", WriteOnly: true}},
+				},
+			},
+			wantErr: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			abs, _ := filepath.Abs(test.inputSchema)
+			jsonSchema, err := jsonutils.ReadSchema(abs)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", test.inputSchema, err)
+			}
+
+			options := test.options
+			options.RootTypeName = jsonSchema.Title
+
+			schemas, err := transform(jsonSchema, abs, options)
+			// TODO; look at some cleaner error testing.
+			if err == nil && test.wantErr != nil {
+				t.Errorf("expected the following error, but did not get any error: %v", test.wantErr)
+			} else if err != nil {
+				if test.wantErr == nil {
+					t.Errorf("got the following error when one wasn't expected: %v", err)
+				} else if test.wantErr.Error() != err.Error() {
+					t.Errorf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+			}
+
+			if !reflect.DeepEqual(test.wantGraphQL, schemas) {
+				t.Errorf("did not get expected schemas.\nwant - %#v\ngot - %#v", test.wantGraphQL, schemas)
+			}
+		})
+	}
+}
+
+func TestTransformWithOptionsRootName(t *testing.T) {
+	type test struct {
+		description string
+		inputSchema string // path to test file
+		options     Options
+		wantName    string
+	}
+
+	schemaTestDir := "./test_data/jsonschema"
+	tests := []test{
+		{
+			description: "should derive a valid type name from a kebab-case file name by default",
+			inputSchema: fmt.Sprintf("%s/simple-schema.json", schemaTestDir),
+			wantName:    "simpleSchema",
+		},
+		{
+			description: "should use the custom root type name as-is",
+			inputSchema: fmt.Sprintf("%s/simple-schema.json", schemaTestDir),
+			options:     Options{RootTypeName: "CustomRoot"},
+			wantName:    "CustomRoot",
+		},
+		{
+			description: "should derive the root type name from the schema title",
+			inputSchema: fmt.Sprintf("%s/one-level-schema.json", schemaTestDir),
+			options:     Options{RootNameSource: RootNameFromTitle},
+			wantName:    "oneLevelSchema",
+		},
+		{
+			description: "should apply the naming function to the schema title",
+			inputSchema: fmt.Sprintf("%s/one-level-schema.json", schemaTestDir),
+			options:     Options{RootNameSource: RootNameFromTitle, NameFunc: strings.ToUpper},
+			wantName:    "ONELEVELSCHEMA",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(test.inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", test.inputSchema, err)
+			}
+
+			schemas, err := TransformWithOptions(jsonSchema, test.inputSchema, test.options)
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			if schemas[0].TypeName != test.wantName {
+				t.Errorf("did not get expected root type name.\nwant - %q\ngot - %q", test.wantName, schemas[0].TypeName)
+			}
+		})
+	}
+}
+
+// absTestPath returns the absolute path of a test data file, as it appears in error messages.
+func absTestPath(dir, name string) string {
+	abs, _ := filepath.Abs(filepath.Join(dir, name))
+	return abs
 }
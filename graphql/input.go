@@ -0,0 +1,276 @@
+package graphql
+
+import "fmt"
+
+// inputKeyword is the root keyword letting a schema turn input types on or off, regardless of Options.InputTypes.
+const inputKeyword = "x-graphql-input"
+
+// withInputTypes appends an input type for every object type in schemas, when input types are enabled for the run or
+// the schema. Fields of an input type referring to an object type are rewritten to refer to that object's input type.
+// Since both sides of the API are generated, read-only fields are left out of input types and write-only fields are
+// removed from object types. Object types with nothing left to send, such as ones made only of read-only fields, get
+// no input type, and fields referring to them are left out of input types as well. The same goes for object types with
+// nothing left to read, which are removed. Unions can't be used in input types, so fields referring to one are left
+// out of them and reported through Options.OnSkippedInputField. Only the input types a client can send are added: the
+// input type of the root type, or of the members of a root union, and the ones their fields refer to.
+func (t *transformer) withInputTypes(schemas []Schema, doc *document) ([]Schema, error) {
+	enabled, err := t.inputTypesEnabled(doc)
+	if err != nil || !enabled {
+		return schemas, err
+	}
+
+	kinds := schemaKinds(schemas)
+	withInput := inputObjects(schemas, kinds)
+	if len(schemas) > 0 {
+		withInput = reachableInputs(schemas, schemas[0], kinds, withInput)
+	}
+
+	// Every input name is taken up front, since input types can refer to each other in any order.
+	inputNames := map[string]string{}
+	for _, schema := range schemas {
+		if !withInput[schema.TypeName] {
+			continue
+		}
+
+		name, err := t.reserveName(schema.TypeName + "Input")
+		if err != nil {
+			return nil, fmt.Errorf("error naming input type of %q: %w", schema.TypeName, err)
+		}
+		inputNames[schema.TypeName] = name
+	}
+
+	for _, schema := range schemas {
+		if !withInput[schema.TypeName] {
+			continue
+		}
+
+		input := Schema{
+			TypeName:    inputNames[schema.TypeName],
+			Description: schema.Description,
+			Kind:        KindInput,
+			Fields:      make([]Field, 0, len(schema.Fields)),
+		}
+
+		for _, field := range schema.Fields {
+			if !inInput(field, kinds, withInput) {
+				if kinds[field.Type] == KindUnion && !field.ReadOnly && t.opts.OnSkippedInputField != nil {
+					t.opts.OnSkippedInputField(schema.TypeName, field)
+				}
+				continue
+			}
+
+			// Required input fields can't be deprecated, since clients can't stop sending them.
+			if field.Required {
+				field.Deprecated, field.DeprecationReason = false, ""
+			}
+
+			if inputName, ok := inputNames[field.Type]; ok {
+				field.Type = inputName
+			}
+
+			input.Fields = append(input.Fields, field)
+		}
+
+		t.completeSchema(&schemas, input)
+	}
+
+	return withoutWriteOnly(schemas, kinds), nil
+}
+
+// schemaKinds maps the name of every type in schemas to its kind.
+func schemaKinds(schemas []Schema) map[string]SchemaKind {
+	kinds := map[string]SchemaKind{}
+	for _, schema := range schemas {
+		kinds[schema.TypeName] = schema.Kind
+	}
+
+	return kinds
+}
+
+// inputObjects returns the names of the object types in schemas that get an input type, which are the ones with at
+// least one field kept by inInput. Leaving a type out can leave the types referring to it without fields as well, so
+// this is repeated until every remaining type has a field.
+func inputObjects(schemas []Schema, kinds map[string]SchemaKind) map[string]bool {
+	withInput := map[string]bool{}
+	for _, schema := range schemas {
+		if schema.Kind == KindObject {
+			withInput[schema.TypeName] = true
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, schema := range schemas {
+			if !withInput[schema.TypeName] || hasField(schema.Fields, func(field Field) bool { return inInput(field, kinds, withInput) }) {
+				continue
+			}
+
+			delete(withInput, schema.TypeName)
+			changed = true
+		}
+	}
+
+	return withInput
+}
+
+// reachableInputs narrows withInput down to the object types a client can send an input for, starting from root, or
+// the members of root when it's a union, and following the fields kept in input types.
+func reachableInputs(schemas []Schema, root Schema, kinds map[string]SchemaKind, withInput map[string]bool) map[string]bool {
+	fields := map[string][]Field{}
+	for _, schema := range schemas {
+		if schema.Kind == KindObject {
+			fields[schema.TypeName] = schema.Fields
+		}
+	}
+
+	pending := []string{root.TypeName}
+	if root.Kind == KindUnion {
+		pending = root.Types
+	}
+
+	reachable := map[string]bool{}
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if !withInput[name] || reachable[name] {
+			continue
+		}
+
+		reachable[name] = true
+		for _, field := range fields[name] {
+			if kinds[field.Type] == KindObject && inInput(field, kinds, withInput) {
+				pending = append(pending, field.Type)
+			}
+		}
+	}
+
+	return reachable
+}
+
+// inInput reports whether field is kept in the input type of the object type it belongs to. Read-only fields are left
+// out, and so are fields referring to unions or to object types without an input type.
+func inInput(field Field, kinds map[string]SchemaKind, withInput map[string]bool) bool {
+	if field.ReadOnly {
+		return false
+	}
+
+	kind, ok := kinds[field.Type]
+	switch {
+	case ok && kind == KindUnion:
+		return false
+	case ok && kind == KindObject:
+		return withInput[field.Type]
+	default:
+		return true
+	}
+}
+
+// hasField reports whether any of fields satisfies keep.
+func hasField(fields []Field, keep func(Field) bool) bool {
+	for _, field := range fields {
+		if keep(field) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withoutWriteOnly removes write-only fields from the object types in schemas. Object types left without fields are
+// removed too, along with the fields and union members referring to them, and so are unions left without members. This
+// is repeated until every remaining type has something left.
+func withoutWriteOnly(schemas []Schema, kinds map[string]SchemaKind) []Schema {
+	kept := map[string]bool{}
+	for _, schema := range schemas {
+		if schema.Kind == KindObject || schema.Kind == KindUnion {
+			kept[schema.TypeName] = true
+		}
+	}
+
+	inOutput := func(field Field) bool {
+		if field.WriteOnly {
+			return false
+		}
+
+		if kind, ok := kinds[field.Type]; ok && (kind == KindObject || kind == KindUnion) {
+			return kept[field.Type]
+		}
+
+		return true
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for _, schema := range schemas {
+			if !kept[schema.TypeName] {
+				continue
+			}
+
+			if (schema.Kind == KindObject && !hasField(schema.Fields, inOutput)) || (schema.Kind == KindUnion && !hasMember(schema.Types, kept)) {
+				delete(kept, schema.TypeName)
+				changed = true
+			}
+		}
+	}
+
+	remaining := make([]Schema, 0, len(schemas))
+	for _, schema := range schemas {
+		switch schema.Kind {
+		case KindObject:
+			if !kept[schema.TypeName] {
+				continue
+			}
+
+			fields := make([]Field, 0, len(schema.Fields))
+			for _, field := range schema.Fields {
+				if inOutput(field) {
+					fields = append(fields, field)
+				}
+			}
+			schema.Fields = fields
+		case KindUnion:
+			if !kept[schema.TypeName] {
+				continue
+			}
+
+			members := make([]string, 0, len(schema.Types))
+			for _, member := range schema.Types {
+				if kept[member] {
+					members = append(members, member)
+				}
+			}
+			schema.Types = members
+		}
+
+		remaining = append(remaining, schema)
+	}
+
+	return remaining
+}
+
+// hasMember reports whether any of the member types of a union is kept.
+func hasMember(members []string, kept map[string]bool) bool {
+	for _, member := range members {
+		if kept[member] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inputTypesEnabled reports whether input types should be generated, giving the schema's own keyword precedence over
+// the run's options.
+func (t *transformer) inputTypesEnabled(doc *document) (bool, error) {
+	value, ok := doc.root.Get(inputKeyword)
+	if !ok {
+		return t.opts.InputTypes, nil
+	}
+
+	enabled, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("the %q keyword must be a boolean, got %v", inputKeyword, value)
+	}
+
+	return enabled, nil
+}
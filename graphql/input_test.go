@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	"jgschema/jsonutils"
+	"reflect"
+	"testing"
+)
+
+func TestTransformInputUnions(t *testing.T) {
+	inputSchema := "./test_data/jsonschema/input-union-schema.json"
+	jsonSchema, err := jsonutils.ReadSchema(inputSchema)
+	if err != nil {
+		t.Fatalf("error reading JSON schema test file at path %q: %v", inputSchema, err)
+	}
+
+	var skipped []string
+	opts := Options{
+		RootTypeName: jsonSchema.Title,
+		UnionMode:    UnionsAsTypes,
+		InputTypes:   true,
+		OnSkippedInputField: func(typeName string, field Field) {
+			skipped = append(skipped, typeName+"."+field.Name)
+		},
+	}
+
+	schemas, err := transform(jsonSchema, inputSchema, opts)
+	if err != nil {
+		t.Fatalf("got the following error when one wasn't expected: %v", err)
+	}
+
+	var inputs []string
+	for _, schema := range schemas {
+		if schema.Kind == KindInput {
+			inputs = append(inputs, schema.TypeName)
+		}
+	}
+
+	// Objects only reachable through unions get no input type, since no input field can refer to them.
+	wantInputs := []string{"adoptionInput", "ownerInput"}
+	if !reflect.DeepEqual(wantInputs, inputs) {
+		t.Errorf("did not get expected input types.\nwant - %v\ngot - %v", wantInputs, inputs)
+	}
+
+	wantSkipped := []string{"adoption.pet", "adoption.previous"}
+	if !reflect.DeepEqual(wantSkipped, skipped) {
+		t.Errorf("did not get expected skipped fields.\nwant - %v\ngot - %v", wantSkipped, skipped)
+	}
+}
@@ -0,0 +1,53 @@
+package graphql
+
+import "fmt"
+
+// ItemNullability determines whether the items of lists are non-null.
+type ItemNullability int
+
+const (
+	// ItemsNullable keeps the items of every list nullable, e.g. [String].
+	ItemsNullable ItemNullability = iota
+	// ItemsFromSchema makes the items of a list non-null, e.g. [String!], unless the items schema allows "null".
+	ItemsFromSchema
+)
+
+// itemsRequired reports whether the items described by the items schema of an array are non-null.
+// A nested list is also non-null when its minItems make it so, see listRequired.
+func (t *transformer) itemsRequired(items any) (bool, error) {
+	types, nullable, err := getTypes(items)
+	if err != nil {
+		return false, fmt.Errorf("error getting array items type: %w", err)
+	}
+
+	switch t.opts.ItemNullability {
+	case ItemsNullable:
+	case ItemsFromSchema:
+		if !nullable {
+			return true, nil
+		}
+	default:
+		return false, fmt.Errorf("unknown item nullability %d", t.opts.ItemNullability)
+	}
+
+	if len(types) == 1 && isArray(types[0]) {
+		return t.listRequired(items, nullable)
+	}
+
+	return false, nil
+}
+
+// listRequired reports whether the minItems of an array make the list non-null, when enabled through
+// Options.MinItemsNonNull. A list allowing "null" is never non-null.
+func (t *transformer) listRequired(property any, nullable bool) (bool, error) {
+	if !t.opts.MinItemsNonNull || nullable {
+		return false, nil
+	}
+
+	minItems, err := getOrderedMapKey[float64](property, "minItems")
+	if err != nil {
+		return false, fmt.Errorf("error getting minItems: %w", err)
+	}
+
+	return *minItems >= 1, nil
+}
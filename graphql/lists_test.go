@@ -0,0 +1,80 @@
+package graphql
+
+import (
+	"jgschema/jsonutils"
+	"testing"
+)
+
+func TestTransformListItems(t *testing.T) {
+	type test struct {
+		description     string
+		itemNullability ItemNullability
+		minItemsNonNull bool
+		wantTypes       map[string]string // field name to its generated type reference
+	}
+
+	inputSchema := "./test_data/jsonschema/list-items-schema.json"
+	tests := []test{
+		{
+			description: "should keep every list and item nullable by default",
+			wantTypes: map[string]string{
+				"tags":    "[String]",
+				"scores":  "[Float]",
+				"ids":     "[Int]",
+				"aliases": "[String]",
+				"matrix":  "[[Float]]",
+			},
+		},
+		{
+			description:     "should make items non-null unless their schema allows null",
+			itemNullability: ItemsFromSchema,
+			wantTypes: map[string]string{
+				"tags":    "[String!]",
+				"scores":  "[Float]",
+				"ids":     "[Int!]",
+				"aliases": "[String!]",
+				"matrix":  "[[Float!]!]",
+			},
+		},
+		{
+			description:     "should make lists with a minItems of at least one non-null",
+			minItemsNonNull: true,
+			wantTypes: map[string]string{
+				"tags":    "[String]",
+				"scores":  "[Float]",
+				"ids":     "[Int]!",
+				"aliases": "[String]",
+				"matrix":  "[[Float]!]",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", inputSchema, err)
+			}
+
+			schemas, err := TransformWithOptions(jsonSchema, inputSchema, Options{
+				RootTypeName:    jsonSchema.Title,
+				ItemNullability: test.itemNullability,
+				MinItemsNonNull: test.minItemsNonNull,
+			})
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			for _, field := range schemas[0].Fields {
+				got, err := buildTypeRef(field)
+				if err != nil {
+					t.Fatalf("error building type ref for field %q: %v", field.Name, err)
+				}
+
+				if want := test.wantTypes[field.Name]; got != want {
+					t.Errorf("field %q has type %q, want %q", field.Name, got, want)
+				}
+			}
+		})
+	}
+}
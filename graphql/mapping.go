@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// builtinScalars are the scalars every GraphQL schema has, which are never declared.
+var builtinScalars = map[string]bool{
+	"Int":     true,
+	"Float":   true,
+	"String":  true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// TypeMapping overrides the GraphQL type fields are rendered with. Every value is the name of a GraphQL type: either a
+// type generated from the schema, such as an object or an enum, or a custom scalar, which is declared when no generated
+// type has that name. Built-in scalars are never declared. When several entries apply to the same field, a path wins
+// over a ref, a ref over a format, and a format over a JSON type.
+type TypeMapping struct {
+	// Types maps JSON types ("integer", "number", "string" or "boolean") to a GraphQL type, e.g. "integer" to "Long".
+	Types map[string]string `json:"types"`
+	// Formats maps "format" values to a GraphQL type, on top of Options.Formats.
+	Formats map[string]string `json:"formats"`
+	// Refs maps $ref values, as written in the schema, to a GraphQL type, e.g. "#/$defs/money" to "Decimal".
+	// The referenced schema isn't walked.
+	Refs map[string]string `json:"refs"`
+	// Paths maps property paths to a GraphQL type, e.g. "order.total" to "Decimal". A path is made of the property
+	// names leading to the field from the root schema, joined with dots. Array items don't add a segment.
+	Paths map[string]string `json:"paths"`
+}
+
+// propertyPath returns the path of the property with the given name in the object currently being walked.
+func (t *transformer) propertyPath(key string) string {
+	return strings.Join(append(t.path[:len(t.path):len(t.path)], key), ".")
+}
+
+// customScalar returns typeName, first declaring it as a custom scalar in schemas unless it is a built-in scalar.
+func (t *transformer) customScalar(typeName string, schemas *[]Schema) (string, error) {
+	if builtinScalars[typeName] {
+		return typeName, nil
+	}
+
+	return t.addSchema(schemas, Schema{TypeName: typeName, Kind: KindScalar})
+}
+
+// mappedType returns typeName, a type named by the type mapping. Whether it is a generated type or a custom scalar is
+// only known once every type is generated, so it is recorded for declareMappedTypes instead of being declared.
+func (t *transformer) mappedType(typeName string) string {
+	if !builtinScalars[typeName] {
+		t.mapped = append(t.mapped, typeName)
+	}
+
+	return typeName
+}
+
+// declareMappedTypes resolves the types named by the type mapping once every type is generated. A name matching a
+// generated type refers to it, with fields spelled the way that type is; any other name is declared as a custom scalar.
+func (t *transformer) declareMappedTypes(schemas *[]Schema) error {
+	resolved := map[string]string{}
+	for _, name := range t.mapped {
+		if _, ok := resolved[name]; ok {
+			continue
+		}
+
+		if existing := t.registry.types[Title(name)]; existing != nil {
+			resolved[name] = existing.TypeName
+			continue
+		}
+
+		declared, err := t.addSchema(schemas, Schema{TypeName: name, Kind: KindScalar})
+		if err != nil {
+			return fmt.Errorf("error adding mapped type %q: %w", name, err)
+		}
+		resolved[name] = declared
+	}
+
+	for i := range *schemas {
+		fields := (*schemas)[i].Fields
+		for j := range fields {
+			if name, ok := resolved[fields[j].Type]; ok {
+				fields[j].Type = name
+			}
+		}
+	}
+
+	return nil
+}
+
+// mappedField builds the field for a property whose type is overridden with typeName by the type mapping.
+// The property is otherwise only read for its description, whether it is nullable and whether it is an array.
+func (t *transformer) mappedField(key string, property any, typeName string, requiredFields []string) (Field, error) {
+	description, _ := getOrderedMapKey[string](property, "description")
+
+	types, nullable, err := getTypes(property)
+	if err != nil {
+		return Field{}, fmt.Errorf("error on field %q getting object field type: %w", key, err)
+	}
+
+	return Field{
+		Name:        key,
+		Type:        t.mappedType(typeName),
+		Description: *description,
+		Required:    contains(key, requiredFields) && !nullable,
+		Array:       len(types) == 1 && isArray(types[0]),
+	}, nil
+}
+
+// scalarType returns the GraphQL type of a field whose JSON type is the scalar typeName, applying the format and JSON
+// type mappings. Without a matching mapping, typeName is returned.
+func (t *transformer) scalarType(fieldName string, property any, typeName string, schemas *[]Schema) (string, error) {
+	formatted, err := t.formatType(fieldName, property, typeName, schemas)
+	if err != nil || formatted != typeName {
+		return formatted, err
+	}
+
+	if mapped, ok := t.opts.TypeMapping.Types[typeName]; ok {
+		return t.mappedType(mapped), nil
+	}
+
+	return typeName, nil
+}
@@ -0,0 +1,96 @@
+package graphql
+
+import (
+	"jgschema/jsonutils"
+	"reflect"
+	"testing"
+)
+
+func TestTransformTypeMapping(t *testing.T) {
+	type test struct {
+		description string
+		mapping     TypeMapping
+		wantTypes   []string
+		wantFields  map[string]string // "type.field" to the type it refers to
+	}
+
+	inputSchema := "./test_data/jsonschema/mapping-schema.json"
+	tests := []test{
+		{
+			description: "should render every type as usual without a mapping",
+			wantTypes:   []string{"invoice", "money", "lines", "DateTime"},
+			wantFields: map[string]string{
+				"invoice.id":    "integer",
+				"invoice.price": "money",
+				"lines.total":   "number",
+				"invoice.refs":  "integer",
+			},
+		},
+		{
+			description: "should override JSON types, formats, refs and paths",
+			mapping: TypeMapping{
+				Types:   map[string]string{"integer": "Long", "number": "Float"},
+				Formats: map[string]string{"int32": "Int", "date-time": "Instant"},
+				Refs:    map[string]string{"#/$defs/money": "Decimal"},
+				Paths:   map[string]string{"lines.total": "Decimal", "id": "ID"},
+			},
+			wantTypes: []string{"invoice", "lines", "Decimal", "Instant", "Long"},
+			wantFields: map[string]string{
+				"invoice.id":       "ID",
+				"invoice.count":    "Int",
+				"invoice.price":    "Decimal",
+				"lines.total":      "Decimal",
+				"lines.discount":   "Float",
+				"invoice.issuedAt": "Instant",
+				"invoice.refs":     "Long",
+			},
+		},
+		{
+			description: "should refer to a generated type instead of declaring a scalar with its name",
+			mapping: TypeMapping{
+				Paths: map[string]string{"lines.discount": "Money"},
+			},
+			wantTypes: []string{"invoice", "money", "lines", "DateTime"},
+			wantFields: map[string]string{
+				"invoice.price":  "money",
+				"lines.discount": "money",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", inputSchema, err)
+			}
+
+			schemas, err := TransformWithOptions(jsonSchema, inputSchema, Options{
+				RootTypeName: jsonSchema.Title,
+				TypeMapping:  test.mapping,
+			})
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			var gotTypes []string
+			gotFields := map[string]string{}
+			for _, schema := range schemas {
+				gotTypes = append(gotTypes, schema.TypeName)
+				for _, field := range schema.Fields {
+					gotFields[schema.TypeName+"."+field.Name] = field.Type
+				}
+			}
+
+			if !reflect.DeepEqual(test.wantTypes, gotTypes) {
+				t.Errorf("did not get expected types.\nwant - %v\ngot - %v", test.wantTypes, gotTypes)
+			}
+
+			for field, want := range test.wantFields {
+				if gotFields[field] != want {
+					t.Errorf("field %q refers to %q, want %q", field, gotFields[field], want)
+				}
+			}
+		})
+	}
+}
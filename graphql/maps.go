@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// MapStrategy determines how free-form objects, which have no properties of their own, are represented in GraphQL.
+// Such objects are usually dictionaries described through "additionalProperties".
+type MapStrategy int
+
+const (
+	// MapsAsScalar turns free-form objects into the custom scalar named by Options.MapScalar.
+	MapsAsScalar MapStrategy = iota
+	// MapsAsKeyValueList turns free-form objects into a list of a generated type with a "key" and a "value" field,
+	// named after the property followed by "KeyValue". The type of the value comes from "additionalProperties", and
+	// is the map scalar when any value is allowed.
+	MapsAsKeyValueList
+)
+
+// defaultMapScalar is the scalar free-form objects are turned into when Options.MapScalar is empty.
+const defaultMapScalar = "JSON"
+
+// isFreeForm reports whether an object property has no properties of its own, and can't be turned into an object type.
+func isFreeForm(property any) bool {
+	properties, err := getOrderedMapKey[orderedmap.OrderedMap](property, "properties")
+	return err != nil || len(properties.Keys()) == 0
+}
+
+// mapScalar declares the scalar free-form objects are turned into in schemas, and returns its name.
+func (t *transformer) mapScalar(schemas *[]Schema) (string, error) {
+	name := t.opts.MapScalar
+	if name == "" {
+		name = defaultMapScalar
+	}
+
+	return t.customScalar(name, schemas)
+}
+
+// mapType returns the type of the free-form object property named key, and whether the field is a list of that type,
+// according to the configured MapStrategy.
+func (t *transformer) mapType(key string, property any, schemas *[]Schema, doc *document) (string, bool, error) {
+	switch t.opts.MapStrategy {
+	case MapsAsScalar:
+		scalar, err := t.mapScalar(schemas)
+		return scalar, false, err
+	case MapsAsKeyValueList:
+		value, err := t.mapValue(key, property, schemas, doc)
+		if err != nil {
+			return "", false, err
+		}
+
+		keyValue := Schema{
+			TypeName: key + "KeyValue",
+			Fields: []Field{
+				{Name: "key", Type: "string", Required: true},
+				value,
+			},
+		}
+
+		name, err := t.addSchema(schemas, keyValue)
+		if err != nil {
+			return "", false, fmt.Errorf("error adding key value type for %q: %w", key, err)
+		}
+
+		return name, true, nil
+	default:
+		return "", false, fmt.Errorf("unknown map strategy %d", t.opts.MapStrategy)
+	}
+}
+
+// mapValue returns the "value" field of the key value type of a free-form object, walking its "additionalProperties"
+// schema the same way as array items. Values are of the map scalar when "additionalProperties" is missing, true or
+// empty.
+func (t *transformer) mapValue(key string, property any, schemas *[]Schema, doc *document) (Field, error) {
+	additional, err := getOrderedMapKey[any](property, "additionalProperties")
+	if err != nil {
+		return Field{}, fmt.Errorf("error getting additionalProperties of %q: %w", key, err)
+	}
+
+	value := Field{Name: "value"}
+
+	values, ok := (*additional).(orderedmap.OrderedMap)
+	if ok && len(values.Keys()) > 0 {
+		schema := Schema{TypeName: key + "Value", Fields: []Field{}}
+		if err := t.walkArray(&values, &schema, schemas, doc); err != nil {
+			return Field{}, fmt.Errorf("error walking down additionalProperties of %q: %w", key, err)
+		}
+
+		if len(schema.Fields) > 0 {
+			value = schema.Fields[0]
+			value.Name = "value"
+			return value, nil
+		}
+	}
+
+	value.Type, err = t.mapScalar(schemas)
+	return value, err
+}
@@ -0,0 +1,137 @@
+package graphql
+
+import (
+	"jgschema/jsonutils"
+	"reflect"
+	"testing"
+)
+
+func TestTransformMaps(t *testing.T) {
+	type test struct {
+		description string
+		strategy    MapStrategy
+		scalar      string
+		wantGraphQL []Schema
+	}
+
+	inputSchema := "./test_data/jsonschema/map-schema.json"
+	tests := []test{
+		{
+			description: "should turn free-form objects into the JSON scalar",
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "resource",
+					Description: "A resource with free-form fields.",
+					Fields: []Field{
+						{Name: "name", Type: "string"},
+						{Name: "labels", Type: "JSON", Description: "Labels by name."},
+						{Name: "limits", Type: "JSON"},
+						{Name: "metadata", Type: "JSON"},
+						{Name: "extra", Type: "JSON"},
+						{Name: "events", Type: "JSON", Array: true},
+					},
+				},
+				{TypeName: "JSON", Kind: KindScalar},
+			},
+		},
+		{
+			description: "should use the configured scalar",
+			scalar:      "Map",
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "resource",
+					Description: "A resource with free-form fields.",
+					Fields: []Field{
+						{Name: "name", Type: "string"},
+						{Name: "labels", Type: "Map", Description: "Labels by name."},
+						{Name: "limits", Type: "Map"},
+						{Name: "metadata", Type: "Map"},
+						{Name: "extra", Type: "Map"},
+						{Name: "events", Type: "Map", Array: true},
+					},
+				},
+				{TypeName: "Map", Kind: KindScalar},
+			},
+		},
+		{
+			description: "should turn free-form objects into key value lists",
+			strategy:    MapsAsKeyValueList,
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "resource",
+					Description: "A resource with free-form fields.",
+					Fields: []Field{
+						{Name: "name", Type: "string"},
+						{Name: "labels", Type: "labelsKeyValue", Description: "Labels by name.", Array: true},
+						{Name: "limits", Type: "limitsKeyValue", Array: true},
+						{Name: "metadata", Type: "metadataKeyValue", Array: true},
+						{Name: "extra", Type: "extraKeyValue", Array: true},
+						{Name: "events", Type: "eventsKeyValue", Array: true, ListDepth: 2},
+					},
+				},
+				{
+					TypeName: "labelsKeyValue",
+					Fields: []Field{
+						{Name: "key", Type: "string", Required: true},
+						{Name: "value", Type: "string"},
+					},
+				},
+				{
+					TypeName: "limitsValue",
+					Fields:   []Field{{Name: "max", Type: "integer"}},
+				},
+				{
+					TypeName: "limitsKeyValue",
+					Fields: []Field{
+						{Name: "key", Type: "string", Required: true},
+						{Name: "value", Type: "limitsValue"},
+					},
+				},
+				{TypeName: "JSON", Kind: KindScalar},
+				{
+					TypeName: "metadataKeyValue",
+					Fields: []Field{
+						{Name: "key", Type: "string", Required: true},
+						{Name: "value", Type: "JSON"},
+					},
+				},
+				{
+					TypeName: "extraKeyValue",
+					Fields: []Field{
+						{Name: "key", Type: "string", Required: true},
+						{Name: "value", Type: "JSON"},
+					},
+				},
+				{
+					TypeName: "eventsKeyValue",
+					Fields: []Field{
+						{Name: "key", Type: "string", Required: true},
+						{Name: "value", Type: "JSON"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", inputSchema, err)
+			}
+
+			schemas, err := TransformWithOptions(jsonSchema, inputSchema, Options{
+				RootTypeName: jsonSchema.Title,
+				MapStrategy:  test.strategy,
+				MapScalar:    test.scalar,
+			})
+			if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.wantGraphQL, schemas) {
+				t.Errorf("did not get expected result.\nwant - %+v\ngot - %+v", test.wantGraphQL, schemas)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"jgschema/jsonutils"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/invopop/jsonschema"
+)
+
+// document is a raw JSON schema file. Refs are resolved against the raw JSON rather than a jsonschema.Schema, so that
+// a JSON pointer can reach any location in the file, including keywords the jsonschema package doesn't know about.
+type document struct {
+	// path is the file the document was read from, which relative file refs are resolved against.
+	path string
+	root *orderedmap.OrderedMap
+}
+
+// pointerUnescaper decodes the "~1" and "~0" escape sequences of a JSON pointer reference token in a single pass,
+// so that "~01" becomes "~1" rather than "/".
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// rootDocument builds the document for the schema passed into transform. The raw file at schemaPath is preferred since
+// it keeps every keyword, but schemas that weren't read from a file, or were built in memory and have an empty
+// schemaPath, are marshaled back into JSON instead.
+func (t *transformer) rootDocument(jsonSchema *jsonschema.Schema, schemaPath string) (*document, error) {
+	if schemaPath != "" {
+		doc, err := t.loadDocument(schemaPath)
+		if err == nil {
+			return doc, nil
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	raw, err := json.Marshal(jsonSchema)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling schema: %w", err)
+	}
+
+	root := orderedmap.New()
+	if err := json.Unmarshal(raw, root); err != nil {
+		return nil, fmt.Errorf("error unmarshaling schema into a document: %w", err)
+	}
+
+	doc := &document{path: schemaPath, root: root}
+	t.documents[schemaPath] = doc
+	return doc, nil
+}
+
+// loadDocument returns the document at path, only reading the file the first time it is requested.
+func (t *transformer) loadDocument(path string) (*document, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("error getting absolute path of schema file: %w", err)
+	}
+
+	if doc, ok := t.documents[path]; ok {
+		return doc, nil
+	}
+
+	root, err := jsonutils.ReadDocument(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading schema file %q: %w", path, err)
+	}
+
+	doc := &document{path: path, root: root}
+	t.documents[path] = doc
+	return doc, nil
+}
+
+// reference is the schema a $ref keyword points to.
+type reference struct {
+	schema *jsonschema.Schema
+	// raw is the referenced schema as found in the document, keeping the keywords the jsonschema package drops.
+	raw any
+	// doc is the document the referenced schema lives in, which any refs inside of the schema are relative to.
+	doc *document
+	// id identifies the referenced location across every document, and is empty for inline schemas.
+	id string
+}
+
+// refID builds the identifier of the location pointer refers to in doc.
+func refID(doc *document, pointer string) string {
+	return doc.path + "#" + pointer
+}
+
+// getRef resolves the value of a $ref keyword found in doc. The ref is made of an optional file path, relative to doc,
+// and an optional URI fragment holding a JSON pointer.
+func (t *transformer) getRef(ref string, doc *document) (*reference, error) {
+	if ref == "" {
+		return nil, errors.New("passed in ref to getRef was empty")
+	}
+
+	filePath, fragment, _ := strings.Cut(ref, "#")
+
+	target := doc
+	if filePath != "" {
+		if !filepath.IsAbs(filePath) {
+			filePath = filepath.Join(filepath.Dir(doc.path), filePath)
+		}
+
+		var err error
+		target, err = t.loadDocument(filePath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pointer, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding fragment %q: %w", fragment, err)
+	}
+
+	node, err := resolvePointer(target.root, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving pointer %q in %q: %w", pointer, target.path, err)
+	}
+
+	schema, err := toJSONSchema(node)
+	if err != nil {
+		return nil, fmt.Errorf("error converting pointer %q in %q: %w", pointer, target.path, err)
+	}
+
+	// A whole file is named the way its root type is when the file is transformed itself, so that files referring to
+	// each other give a type the same name whichever file it's reached from. Other referenced schemas are named after
+	// their title, which is free text such as "Basic Schema", or the last token of the pointer without one, both
+	// turned into a valid GraphQL name.
+	if pointer == "" {
+		schema.Title = t.documentTypeName(schema, target.path)
+	} else if schema.Title = toTypeName(schema.Title); schema.Title == "" {
+		schema.Title = toTypeName(pointerUnescaper.Replace(pointer[strings.LastIndex(pointer, "/")+1:]))
+	}
+
+	return &reference{schema: schema, raw: node, doc: target, id: refID(target, pointer)}, nil
+}
+
+// documentTypeName returns the name of the root type of the schema file at path, following Options.RootNameSource.
+// Options.RootTypeName only names the transformed schema, and files whose title is missing are named after the file.
+func (t *transformer) documentTypeName(schema *jsonschema.Schema, path string) string {
+	opts := t.opts
+	opts.RootTypeName = ""
+
+	name, err := rootTypeName(schema, path, opts)
+	if err != nil {
+		return toTypeName(fileNameNoExtension(path))
+	}
+
+	return name
+}
+
+// resolvePointer evaluates an RFC 6901 JSON pointer against a raw JSON node.
+// An empty pointer refers to the whole node.
+func resolvePointer(node any, pointer string) (any, error) {
+	if pointer == "" {
+		return node, nil
+	} else if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("pointer %q must be empty or start with \"/\"", pointer)
+	}
+
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = pointerUnescaper.Replace(token)
+
+		switch value := node.(type) {
+		case orderedmap.OrderedMap:
+			child, ok := value.Get(token)
+			if !ok {
+				return nil, fmt.Errorf("key %q not found", token)
+			}
+			node = child
+		case *orderedmap.OrderedMap:
+			child, ok := value.Get(token)
+			if !ok {
+				return nil, fmt.Errorf("key %q not found", token)
+			}
+			node = child
+		case []any:
+			index, err := arrayIndex(token)
+			if err != nil {
+				return nil, err
+			}
+
+			if index >= len(value) {
+				return nil, fmt.Errorf("index %d is out of range for an array of length %d", index, len(value))
+			}
+			node = value[index]
+		default:
+			return nil, fmt.Errorf("can't resolve %q in a value of type %T", token, node)
+		}
+	}
+
+	return node, nil
+}
+
+// arrayIndex parses a JSON pointer reference token used on an array, which can't have leading zeros.
+func arrayIndex(token string) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("%q is not a valid array index", token)
+	}
+
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("%q is not a valid array index", token)
+	}
+
+	return index, nil
+}
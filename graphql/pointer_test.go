@@ -0,0 +1,90 @@
+package graphql
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+func TestResolvePointer(t *testing.T) {
+	type test struct {
+		description string
+		pointer     string
+		want        any
+		wantErr     bool
+	}
+
+	document := orderedmap.New()
+	if err := json.Unmarshal([]byte(`{
+		"foo": ["bar", "baz"],
+		"": 0,
+		"a/b": 1,
+		"m~n": 2,
+		"nested": {"inner": {"value": 3}}
+	}`), document); err != nil {
+		t.Fatalf("error unmarshaling test document: %v", err)
+	}
+
+	tests := []test{
+		{
+			description: "should resolve an array element",
+			pointer:     "/foo/1",
+			want:        "baz",
+		},
+		{
+			description: "should resolve an empty key",
+			pointer:     "/",
+			want:        float64(0),
+		},
+		{
+			description: "should unescape ~1 into a slash",
+			pointer:     "/a~1b",
+			want:        float64(1),
+		},
+		{
+			description: "should unescape ~0 into a tilde",
+			pointer:     "/m~0n",
+			want:        float64(2),
+		},
+		{
+			description: "should resolve nested objects",
+			pointer:     "/nested/inner/value",
+			want:        float64(3),
+		},
+		{
+			description: "should fail on a missing key",
+			pointer:     "/missing",
+			wantErr:     true,
+		},
+		{
+			description: "should fail on an array index with a leading zero",
+			pointer:     "/foo/01",
+			wantErr:     true,
+		},
+		{
+			description: "should fail on an out of range array index",
+			pointer:     "/foo/2",
+			wantErr:     true,
+		},
+		{
+			description: "should fail on a pointer not starting with a slash",
+			pointer:     "foo",
+			wantErr:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			got, err := resolvePointer(document, test.pointer)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("unexpected error result, wantErr %v, got %v", test.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("did not get expected value.\nwant - %#v\ngot - %#v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// NameConflictStrategy determines how two different types wanting the same name are told apart.
+type NameConflictStrategy int
+
+const (
+	// NameConflictPrefixParent prefixes the name of the later type with the name of the type it is nested in,
+	// e.g. "companyAddress", falling back to NameConflictNumber if that name is taken as well.
+	NameConflictPrefixParent NameConflictStrategy = iota
+	// NameConflictNumber appends the lowest number making the name of the later type unique, e.g. "address2".
+	NameConflictNumber
+	// NameConflictError fails the transformation on the first conflicting name.
+	NameConflictError
+)
+
+// Rename describes a type that was given a different name than it asked for, to avoid a conflict with another type.
+type Rename struct {
+	// Original is the name the type would have had without the conflict.
+	Original string
+	// Renamed is the name the type was given instead.
+	Renamed string
+	// Parent is the name of the type the renamed type is nested in.
+	Parent string
+}
+
+// typeRegistry keeps track of the name of every generated type. Type names are compared the way they are generated,
+// with the first letter uppercased, so that "address" and "Address" conflict.
+type typeRegistry struct {
+	// types maps every taken name to its type. Types that are still being built, such as a referenced type walking
+	// down its own properties, are nil.
+	types map[string]*Schema
+	// variants maps a requested name to every name handed out for it, which are the candidates for deduplication.
+	variants map[string][]string
+}
+
+func newTypeRegistry() *typeRegistry {
+	return &typeRegistry{types: map[string]*Schema{}, variants: map[string][]string{}}
+}
+
+// currentParent returns the name of the type currently being walked, which nested types are named relative to.
+func (t *transformer) currentParent() string {
+	if len(t.parents) == 0 {
+		return ""
+	}
+
+	return t.parents[len(t.parents)-1]
+}
+
+// addSchema registers schema and appends it to schemas, returning the name it ended up with. When a structurally
+// identical type was already registered for the same name, that type's name is returned and nothing is appended.
+func (t *transformer) addSchema(schemas *[]Schema, schema Schema) (string, error) {
+	for _, candidate := range t.registry.variants[Title(schema.TypeName)] {
+		if existing := t.registry.types[Title(candidate)]; existing != nil && sameStructure(*existing, schema) {
+			return candidate, nil
+		}
+	}
+
+	name, err := t.reserveName(schema.TypeName)
+	if err != nil {
+		return "", err
+	}
+
+	schema.TypeName = name
+	t.completeSchema(schemas, schema)
+	return name, nil
+}
+
+// reserveName takes a unique name for a type whose schema is only complete later on, resolving conflicts according to
+// the configured strategy. The type must be passed to completeSchema once it is built.
+func (t *transformer) reserveName(name string) (string, error) {
+	key := Title(name)
+	if _, taken := t.registry.types[key]; !taken {
+		t.registry.types[key] = nil
+		t.registry.variants[key] = append(t.registry.variants[key], name)
+		return name, nil
+	}
+
+	parent := t.currentParent()
+
+	var renamed string
+	switch t.opts.NameConflictStrategy {
+	case NameConflictPrefixParent:
+		if parent != "" && Title(parent) != key {
+			renamed = parent + Title(name)
+		}
+		if _, taken := t.registry.types[Title(renamed)]; renamed == "" || taken {
+			renamed = t.numberedName(name)
+		}
+	case NameConflictNumber:
+		renamed = t.numberedName(name)
+	case NameConflictError:
+		return "", fmt.Errorf("type name %q is already used by a different type", key)
+	default:
+		return "", fmt.Errorf("unknown name conflict strategy %d", t.opts.NameConflictStrategy)
+	}
+
+	t.registry.types[Title(renamed)] = nil
+	t.registry.variants[key] = append(t.registry.variants[key], renamed)
+
+	if t.opts.OnRename != nil {
+		t.opts.OnRename(Rename{Original: name, Renamed: renamed, Parent: parent})
+	}
+
+	return renamed, nil
+}
+
+// completeSchema records the finished schema of a reserved name and appends it to schemas.
+func (t *transformer) completeSchema(schemas *[]Schema, schema Schema) {
+	t.registry.types[Title(schema.TypeName)] = &schema
+	*schemas = append(*schemas, schema)
+}
+
+// numberedName appends the lowest number, starting from 2, that makes name unique.
+func (t *transformer) numberedName(name string) string {
+	for i := 2; ; i++ {
+		numbered := fmt.Sprintf("%s%d", name, i)
+		if _, taken := t.registry.types[Title(numbered)]; !taken {
+			return numbered
+		}
+	}
+}
+
+// sameStructure reports whether two schemas would generate the same type, apart from their name and description.
+func sameStructure(a, b Schema) bool {
+	a.TypeName, a.Description = "", ""
+	b.TypeName, b.Description = "", ""
+	return reflect.DeepEqual(a, b)
+}
+
+// Merge combines the schemas transformed from several JSON schemas, such as files referring to the same definitions,
+// into a single list. A type generated by more than one of them is only kept once, as long as every copy is identical.
+// Different types sharing a name can't be generated together, so they are an error.
+func Merge(sets ...[]Schema) ([]Schema, error) {
+	var merged []Schema
+	seen := map[string]Schema{}
+	for _, schemas := range sets {
+		for _, schema := range schemas {
+			key := Title(schema.TypeName)
+			existing, ok := seen[key]
+			if !ok {
+				seen[key] = schema
+				merged = append(merged, schema)
+				continue
+			}
+
+			if !reflect.DeepEqual(canonicalNames(existing), canonicalNames(schema)) {
+				return nil, fmt.Errorf("type name %q is used by different types", key)
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+// canonicalNames returns a copy of schema with every type name written the way it is generated, so that types
+// referring to "address" and "Address" compare equal.
+func canonicalNames(schema Schema) Schema {
+	schema.TypeName = Title(schema.TypeName)
+
+	fields := make([]Field, len(schema.Fields))
+	for i, field := range schema.Fields {
+		field.Type = Title(field.Type)
+		fields[i] = field
+	}
+	schema.Fields = fields
+
+	types := make([]string, len(schema.Types))
+	for i, member := range schema.Types {
+		types[i] = Title(member)
+	}
+	schema.Types = types
+
+	return schema
+}
@@ -0,0 +1,156 @@
+package graphql
+
+import (
+	"fmt"
+	"jgschema/jsonutils"
+	"reflect"
+	"testing"
+)
+
+func TestTransformNameConflicts(t *testing.T) {
+	type test struct {
+		description string
+		strategy    NameConflictStrategy
+		wantTypes   []string
+		wantFields  map[string]string // "type.field" to the type it refers to
+		wantRenames []Rename
+		wantErr     error
+	}
+
+	inputSchema := "./test_data/jsonschema/conflict-schema.json"
+	tests := []test{
+		{
+			description: "should prefix a conflicting type with its parent and reuse identical types",
+			strategy:    NameConflictPrefixParent,
+			wantTypes:   []string{"conflictSchema", "address", "user", "companyAddress", "company", "shipping", "place"},
+			wantFields: map[string]string{
+				"user.address":        "address",
+				"company.address":     "companyAddress",
+				"shipping.address":    "address",
+				"conflictSchema.home": "place",
+				"conflictSchema.work": "place",
+			},
+			wantRenames: []Rename{{Original: "address", Renamed: "companyAddress", Parent: "company"}},
+		},
+		{
+			description: "should number a conflicting type",
+			strategy:    NameConflictNumber,
+			wantTypes:   []string{"conflictSchema", "address", "user", "address2", "company", "shipping", "place"},
+			wantFields: map[string]string{
+				"company.address": "address2",
+			},
+			wantRenames: []Rename{{Original: "address", Renamed: "address2", Parent: "company"}},
+		},
+		{
+			description: "should fail on a conflicting type",
+			strategy:    NameConflictError,
+			wantErr:     fmt.Errorf(`error when walking down the properties tree: error walking down nested object "company": error adding nested object "address": type name "Address" is already used by a different type`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", inputSchema, err)
+			}
+
+			var renames []Rename
+			schemas, err := TransformWithOptions(jsonSchema, inputSchema, Options{
+				RootTypeName:         jsonSchema.Title,
+				NameConflictStrategy: test.strategy,
+				OnRename:             func(rename Rename) { renames = append(renames, rename) },
+			})
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			var gotTypes []string
+			gotFields := map[string]string{}
+			for _, schema := range schemas {
+				gotTypes = append(gotTypes, schema.TypeName)
+				for _, field := range schema.Fields {
+					gotFields[schema.TypeName+"."+field.Name] = field.Type
+				}
+			}
+
+			if !reflect.DeepEqual(test.wantTypes, gotTypes) {
+				t.Errorf("did not get expected types.\nwant - %v\ngot - %v", test.wantTypes, gotTypes)
+			}
+
+			for field, want := range test.wantFields {
+				if gotFields[field] != want {
+					t.Errorf("field %q refers to %q, want %q", field, gotFields[field], want)
+				}
+			}
+
+			if !reflect.DeepEqual(test.wantRenames, renames) {
+				t.Errorf("did not get expected renames.\nwant - %v\ngot - %v", test.wantRenames, renames)
+			}
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	type test struct {
+		description string
+		paths       []string
+		wantTypes   []string
+		wantErr     error
+	}
+
+	tests := []test{
+		{
+			description: "should keep a type generated by several schemas once",
+			paths:       []string{"./test_data/jsonschema/schema-with-allOf.json", "./test_data/jsonschema/simple-schema.json"},
+			wantTypes:   []string{"allOfSchema", "sampleObjectField", "simpleSchema"},
+		},
+		{
+			description: "should fail on different types sharing a name",
+			paths:       []string{"./test_data/jsonschema/simple-schema.json", "./test_data/jsonschema/simple-schema-required.json"},
+			wantErr:     fmt.Errorf(`type name "SimpleSchema" is used by different types`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			var sets [][]Schema
+			for _, path := range test.paths {
+				jsonSchema, err := jsonutils.ReadSchema(path)
+				if err != nil {
+					t.Fatalf("error reading JSON schema test file at path %q: %v", path, err)
+				}
+
+				schemas, err := TransformWithOptions(jsonSchema, path, Options{RootTypeName: jsonSchema.Title})
+				if err != nil {
+					t.Fatalf("got the following error when one wasn't expected: %v", err)
+				}
+				sets = append(sets, schemas)
+			}
+
+			merged, err := Merge(sets...)
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			var gotTypes []string
+			for _, schema := range merged {
+				gotTypes = append(gotTypes, schema.TypeName)
+			}
+
+			if !reflect.DeepEqual(test.wantTypes, gotTypes) {
+				t.Errorf("did not get expected types.\nwant - %v\ngot - %v", test.wantTypes, gotTypes)
+			}
+		})
+	}
+}
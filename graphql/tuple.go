@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// TupleStrategy determines how tuples, arrays whose items have a schema per position, are transformed when their
+// positions don't all share the same schema. Tuples whose positions do are lists of that schema.
+type TupleStrategy int
+
+const (
+	// TuplesAsObjects turns a tuple into an object type named after the property, with a field per position named
+	// "item1", "item2", and so on.
+	TuplesAsObjects TupleStrategy = iota
+	// TuplesAsUnionLists turns a tuple into a list of a union of the types of its positions, named after the property.
+	// Every position must be an object.
+	TuplesAsUnionLists
+)
+
+// tupleItems returns the schemas of the positions of a tuple, described through "prefixItems", or before draft
+// 2020-12, the array form of "items". It returns nil for arrays that aren't tuples.
+func tupleItems(property any) ([]any, error) {
+	prefixItems, err := getOrderedMapKey[[]any](property, "prefixItems")
+	if err != nil {
+		return nil, fmt.Errorf("error getting prefixItems: %w", err)
+	}
+
+	if len(*prefixItems) > 0 {
+		return *prefixItems, nil
+	}
+
+	items, err := getOrderedMapKey[any](property, "items")
+	if err != nil {
+		return nil, fmt.Errorf("error getting items: %w", err)
+	}
+
+	positions, _ := (*items).([]any)
+	return positions, nil
+}
+
+// walkTuple turns field into the type of a tuple named typeName, whose positions are described by positions.
+// Items allowed past the last position are ignored.
+func (t *transformer) walkTuple(field *Field, typeName string, positions []any, schemas *[]Schema, doc *document) error {
+	homogeneous := true
+	for _, position := range positions[1:] {
+		if !reflect.DeepEqual(position, positions[0]) {
+			homogeneous = false
+			break
+		}
+	}
+
+	if homogeneous {
+		item, err := t.walkPosition(typeName, positions[0], schemas, doc)
+		if err != nil {
+			return fmt.Errorf("error walking down tuple %q: %w", typeName, err)
+		}
+
+		wrapItem(field, item)
+		return nil
+	}
+
+	switch t.opts.TupleStrategy {
+	case TuplesAsObjects:
+		tuple := Schema{TypeName: typeName, Fields: []Field{}}
+		for i, position := range positions {
+			item, err := t.walkPosition(fmt.Sprintf("%s%d", typeName, i+1), position, schemas, doc)
+			if err != nil {
+				return fmt.Errorf("error walking down position %d of tuple %q: %w", i, typeName, err)
+			}
+
+			item.Name = fmt.Sprintf("item%d", i+1)
+			tuple.Fields = append(tuple.Fields, item)
+		}
+
+		name, err := t.addSchema(schemas, tuple)
+		if err != nil {
+			return fmt.Errorf("error adding tuple %q: %w", typeName, err)
+		}
+
+		field.Type, field.Array, field.ListDepth, field.ItemsRequired = name, false, 0, nil
+		return nil
+	case TuplesAsUnionLists:
+		union := Schema{TypeName: typeName, Kind: KindUnion}
+		for i, position := range positions {
+			item, err := t.walkPosition(fmt.Sprintf("%s%d", typeName, i+1), position, schemas, doc)
+			if err != nil {
+				return fmt.Errorf("error walking down position %d of tuple %q: %w", i, typeName, err)
+			}
+
+			// Types still being built, such as recursive refs, are always objects.
+			member, ok := t.registry.types[Title(item.Type)]
+			if item.Array || !ok || (member != nil && member.Kind != KindObject) {
+				return fmt.Errorf("position %d of tuple %q is not an object, only objects can be union members", i, typeName)
+			}
+
+			if !contains(item.Type, union.Types) {
+				union.Types = append(union.Types, item.Type)
+			}
+		}
+
+		name, err := t.addSchema(schemas, union)
+		if err != nil {
+			return fmt.Errorf("error adding tuple union %q: %w", typeName, err)
+		}
+
+		wrapItem(field, Field{Type: name})
+		return nil
+	default:
+		return fmt.Errorf("unknown tuple strategy %d", t.opts.TupleStrategy)
+	}
+}
+
+// walkPosition walks down the schema of a single tuple position the same way as array items, naming the type it
+// might need after typeName.
+func (t *transformer) walkPosition(typeName string, position any, schemas *[]Schema, doc *document) (Field, error) {
+	items, ok := position.(orderedmap.OrderedMap)
+	if !ok {
+		return Field{}, fmt.Errorf("the position %v is not a schema", position)
+	}
+
+	schema := Schema{TypeName: typeName, Fields: []Field{}}
+	if err := t.walkArray(&items, &schema, schemas, doc); err != nil {
+		return Field{}, err
+	}
+
+	item, err := t.arrayItem(schema.Fields, schemas)
+	if err != nil {
+		return Field{}, err
+	}
+
+	item.Required, err = t.itemsRequired(items)
+	return item, err
+}
@@ -0,0 +1,115 @@
+package graphql
+
+import (
+	"fmt"
+	"jgschema/jsonutils"
+	"reflect"
+	"testing"
+)
+
+func TestTransformTuples(t *testing.T) {
+	type test struct {
+		description string
+		inputSchema string
+		strategy    TupleStrategy
+		wantGraphQL []Schema
+		wantErr     error
+	}
+
+	schemaTestDir := "./test_data/jsonschema"
+	tests := []test{
+		{
+			description: "should turn homogeneous tuples into lists and heterogeneous ones into objects",
+			inputSchema: fmt.Sprintf("%s/tuple-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "tupleSchema",
+					Description: "A schema with tuples.",
+					Fields: []Field{
+						{Name: "point", Type: "number", Array: true},
+						{Name: "range", Type: "integer", Array: true},
+						{Name: "shapes", Type: "shapes"},
+					},
+				},
+				{TypeName: "shapes1", Fields: []Field{{Name: "radius", Type: "number"}}},
+				{TypeName: "shapes2", Fields: []Field{{Name: "side", Type: "number"}}},
+				{
+					TypeName: "shapes",
+					Fields: []Field{
+						{Name: "item1", Type: "shapes1"},
+						{Name: "item2", Type: "shapes2"},
+					},
+				},
+			},
+		},
+		{
+			description: "should turn heterogeneous tuples into union lists",
+			inputSchema: fmt.Sprintf("%s/tuple-schema.json", schemaTestDir),
+			strategy:    TuplesAsUnionLists,
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "tupleSchema",
+					Description: "A schema with tuples.",
+					Fields: []Field{
+						{Name: "point", Type: "number", Array: true},
+						{Name: "range", Type: "integer", Array: true},
+						{Name: "shapes", Type: "shapes", Array: true},
+					},
+				},
+				{TypeName: "shapes1", Fields: []Field{{Name: "radius", Type: "number"}}},
+				{TypeName: "shapes2", Fields: []Field{{Name: "side", Type: "number"}}},
+				{TypeName: "shapes", Kind: KindUnion, Types: []string{"shapes1", "shapes2"}},
+			},
+		},
+		{
+			description: "should turn tuples of different scalars into objects",
+			inputSchema: fmt.Sprintf("%s/tuple-scalar-schema.json", schemaTestDir),
+			wantGraphQL: []Schema{
+				{
+					TypeName:    "tupleScalarSchema",
+					Description: "A schema with a tuple of different scalars.",
+					Fields:      []Field{{Name: "entry", Type: "entry"}},
+				},
+				{
+					TypeName: "entry",
+					Fields: []Field{
+						{Name: "item1", Type: "string"},
+						{Name: "item2", Type: "integer"},
+					},
+				},
+			},
+		},
+		{
+			description: "should fail on union lists of scalars",
+			inputSchema: fmt.Sprintf("%s/tuple-scalar-schema.json", schemaTestDir),
+			strategy:    TuplesAsUnionLists,
+			wantErr:     fmt.Errorf(`error when walking down the properties tree: error walking down array "entry": position 0 of tuple "entry" is not an object, only objects can be union members`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			jsonSchema, err := jsonutils.ReadSchema(test.inputSchema)
+			if err != nil {
+				t.Fatalf("error reading JSON schema test file at path %q: %v", test.inputSchema, err)
+			}
+
+			schemas, err := TransformWithOptions(jsonSchema, test.inputSchema, Options{
+				RootTypeName:  jsonSchema.Title,
+				TupleStrategy: test.strategy,
+			})
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			if !reflect.DeepEqual(test.wantGraphQL, schemas) {
+				t.Errorf("did not get expected result.\nwant - %+v\ngot - %+v", test.wantGraphQL, schemas)
+			}
+		})
+	}
+}
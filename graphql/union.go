@@ -0,0 +1,226 @@
+package graphql
+
+import (
+	"fmt"
+
+	"github.com/invopop/jsonschema"
+)
+
+// UnionMode determines how the oneOf and anyOf keywords are transformed. allOf is always merged into the parent.
+type UnionMode int
+
+const (
+	// UnionsMerge adds every oneOf and anyOf branch to the parent as a field, the same way allOf is handled.
+	UnionsMerge UnionMode = iota
+	// UnionsAsTypes turns oneOf and anyOf with object branches into a GraphQL union, referenced by the parent.
+	// Properties with a branch that isn't an object keep their own type, or are of the map scalar without one.
+	UnionsAsTypes
+)
+
+// MultiTypeStrategy determines how properties allowing several non-null types, e.g. "type": ["string", "integer"],
+// are transformed. GraphQL has no way of expressing a field that is one of several scalars.
+type MultiTypeStrategy int
+
+const (
+	// MultiTypeError fails the transformation on properties with several non-null types.
+	MultiTypeError MultiTypeStrategy = iota
+	// MultiTypeUnion wraps each of the property's types in an object with a single "value" field, and references a
+	// union of those objects. The union is named after the property, and each object after the property and its type.
+	MultiTypeUnion
+)
+
+// multiTypeUnion adds the schemas representing a property with several types to schemas, according to the configured
+// strategy, and returns the name of the union.
+func (t *transformer) multiTypeUnion(key string, types []string, schemas *[]Schema) (string, error) {
+	switch t.opts.MultiTypeStrategy {
+	case MultiTypeError:
+		return "", fmt.Errorf("multiple types %v are not supported, either use a single type or enable the multi-type union strategy", types)
+	case MultiTypeUnion:
+	default:
+		return "", fmt.Errorf("unknown multi-type strategy %d", t.opts.MultiTypeStrategy)
+	}
+
+	union := Schema{TypeName: key, Kind: KindUnion}
+	for _, typeName := range types {
+		if typeName == typeObject || typeName == typeArray {
+			return "", fmt.Errorf("type %q can't be part of a multi-type union, only scalar types are supported", typeName)
+		}
+
+		memberName, err := t.addSchema(schemas, Schema{
+			TypeName: key + Title(typeName),
+			Fields:   []Field{{Name: "value", Type: typeName, Required: true}},
+		})
+		if err != nil {
+			return "", err
+		}
+		union.Types = append(union.Types, memberName)
+	}
+
+	return t.addSchema(schemas, union)
+}
+
+// rootUnions handles the oneOf and anyOf keywords at the top of a schema when unions are enabled.
+// A root schema without properties becomes the union itself, otherwise the union is added to it as a field.
+func (t *transformer) rootUnions(jsonSchema *jsonschema.Schema, parent *Schema, schemas *[]Schema, doc *document) error {
+	compositions := []struct {
+		keyword  string
+		branches []*jsonschema.Schema
+	}{
+		{keyword: "oneOf", branches: jsonSchema.OneOf},
+		{keyword: "anyOf", branches: jsonSchema.AnyOf},
+	}
+
+	noFields := (jsonSchema.Properties == nil || len(jsonSchema.Properties.Keys()) == 0) && len(jsonSchema.AllOf) == 0
+	singleComposition := (len(jsonSchema.OneOf) == 0) != (len(jsonSchema.AnyOf) == 0)
+
+	for _, composition := range compositions {
+		if len(composition.branches) == 0 {
+			continue
+		}
+
+		if noFields && singleComposition {
+			union, err := t.buildUnion(parent.TypeName, parent.Description, composition.branches, schemas, doc)
+			if err != nil {
+				return fmt.Errorf("error building %s union: %w", composition.keyword, err)
+			}
+
+			*parent = union
+			return nil
+		}
+
+		union, err := t.buildUnion(parent.TypeName+Title(composition.keyword), "", composition.branches, schemas, doc)
+		if err != nil {
+			return fmt.Errorf("error building %s union: %w", composition.keyword, err)
+		}
+
+		unionName, err := t.addSchema(schemas, union)
+		if err != nil {
+			return fmt.Errorf("error adding %s union: %w", composition.keyword, err)
+		}
+
+		parent.Fields = append(parent.Fields, Field{
+			Name: composition.keyword,
+			Type: unionName,
+		})
+	}
+
+	return nil
+}
+
+// propertyUnion builds a union out of a property's oneOf or anyOf keyword. The returned bool is false when the
+// property has neither keyword, or when one of the branches isn't an object and can't be a union member, e.g. a oneOf
+// of a string and a ref. Such properties are transformed as if unions were disabled.
+func (t *transformer) propertyUnion(key string, property any, schemas *[]Schema, doc *document) (Schema, bool, error) {
+	var raw []any
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		branches, err := getOrderedMapKey[[]any](property, keyword)
+		if err != nil {
+			return Schema{}, false, fmt.Errorf("error getting %s branches: %w", keyword, err)
+		}
+
+		if len(*branches) > 0 {
+			if raw != nil {
+				return Schema{}, false, fmt.Errorf("a property can't combine oneOf and anyOf")
+			}
+			raw = *branches
+		}
+	}
+
+	if raw == nil {
+		return Schema{}, false, nil
+	}
+
+	branches := make([]*jsonschema.Schema, 0, len(raw))
+	for _, branch := range raw {
+		converted, err := toJSONSchema(branch)
+		if err != nil {
+			return Schema{}, false, err
+		}
+		branches = append(branches, converted)
+	}
+
+	objects, err := t.objectBranches(branches, doc)
+	if err != nil || !objects {
+		return Schema{}, false, err
+	}
+
+	union, err := t.buildUnion(key, "", branches, schemas, doc)
+	if err != nil {
+		return Schema{}, false, err
+	}
+
+	return union, true, nil
+}
+
+// buildUnion creates a union schema with a member type for every branch. Branches are either references, or inline
+// object schemas which are named after their title, falling back to the union name followed by their position.
+func (t *transformer) buildUnion(typeName, description string, branches []*jsonschema.Schema, schemas *[]Schema, doc *document) (Schema, error) {
+	union := Schema{
+		TypeName:    typeName,
+		Description: description,
+		Kind:        KindUnion,
+	}
+
+	for i, branch := range branches {
+		ref := &reference{schema: branch, doc: doc}
+		if branch.Ref != "" {
+			var err error
+			ref, err = t.getRef(branch.Ref, doc)
+			if err != nil {
+				return Schema{}, fmt.Errorf("error getting ref %q: %w", branch.Ref, err)
+			}
+		} else if branch.Title == "" {
+			branch.Title = fmt.Sprintf("%s%d", typeName, i+1)
+		}
+
+		if !isObjectSchema(ref.schema) {
+			return Schema{}, fmt.Errorf("branch %d of union %q is not an object, only objects can be union members", i, typeName)
+		}
+
+		memberName, err := t.buildRefSchema(ref, schemas)
+		if err != nil {
+			return Schema{}, fmt.Errorf("error processing union member %q: %w", ref.schema.Title, err)
+		}
+
+		union.Types = append(union.Types, memberName)
+	}
+
+	return union, nil
+}
+
+// objectBranches reports whether every branch, once its ref is resolved, is an object that can be a union member.
+func (t *transformer) objectBranches(branches []*jsonschema.Schema, doc *document) (bool, error) {
+	for _, branch := range branches {
+		schema := branch
+		if branch.Ref != "" {
+			ref, err := t.getRef(branch.Ref, doc)
+			if err != nil {
+				return false, fmt.Errorf("error getting ref %q: %w", branch.Ref, err)
+			}
+			schema = ref.schema
+		}
+
+		if !isObjectSchema(schema) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// isObjectSchema reports whether schema describes an object with properties, the only kind of union member.
+func isObjectSchema(schema *jsonschema.Schema) bool {
+	return (schema.Type == "" || schema.Type == typeObject) && schema.Properties != nil
+}
+
+// hasComposition reports whether a raw property has a oneOf or anyOf keyword.
+func hasComposition(property any) bool {
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		branches, _ := getOrderedMapKey[[]any](property, keyword)
+		if len(*branches) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
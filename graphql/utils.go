@@ -1,9 +1,8 @@
 package graphql
 
 import (
-	"errors"
+	"encoding/json"
 	"fmt"
-	"jgschema/jsonutils"
 	"path/filepath"
 	"strings"
 	"unicode"
@@ -11,80 +10,140 @@ import (
 	"github.com/invopop/jsonschema"
 )
 
-func getRef(path, defKeyword, schemaPath string, definitions jsonschema.Definitions) (*jsonschema.Schema, error) {
-	if path == "" {
-		return nil, errors.New("passed in path to parseRefPath was empty")
-	} else if defKeyword == "" {
-		return nil, errors.New("passed in defintition keyword to parseRefPath was empty")
+// toJSONSchema converts a raw JSON node, such as a property or definition, into a jsonschema.Schema.
+func toJSONSchema(node any) (*jsonschema.Schema, error) {
+	raw, err := json.Marshal(node)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling node: %w", err)
 	}
 
-	var isDefinition, isExternal bool
-
-	if strings.Contains(path, ".json") {
-		isExternal = true
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("error unmarshaling node to json schema: %w", err)
 	}
 
-	if strings.Contains(path, fmt.Sprintf("#/%s", defKeyword)) {
-		isDefinition = true
+	return &schema, nil
+}
+
+// walkRef generalizes the logic for processing allOf, oneOf, and anyOf refs.
+// Since walk isn't smart enough to know when a ref is being passed down, we manually
+// append the results of the walk to the parent (root) and schemas list. The field added to the parent
+// is named fieldName.
+func (t *transformer) walkRef(ref *reference, fieldName string, parent *Schema, schemas *[]Schema) error {
+	typeName, err := t.buildRefSchema(ref, schemas)
+	if err != nil {
+		return err
 	}
 
-	var schema *jsonschema.Schema
-	switch {
-	case isExternal && isDefinition:
+	parent.Fields = append(parent.Fields, Field{
+		Name:        fieldName,
+		Description: ref.schema.Description,
+		Type:        typeName,
+	})
 
-	case isExternal:
-		if !filepath.IsAbs(path) {
-			split := strings.Split(path, "/")
-			path = filepath.Clean(fmt.Sprintf("%s/%s", schemaPath, split[len(split)-1]))
-		}
-		return jsonutils.ReadSchema(path)
-	case isDefinition:
-		split := strings.Split(path, "/")
-		definitionName := split[len(split)-1]
-
-		schema = definitions[definitionName]
-		if schema == nil {
-			return nil, fmt.Errorf("there is no definition named %q in this schema", definitionName)
+	return nil
+}
+
+// buildRefSchema walks down the properties of a referenced schema and adds the resulting GraphQL schema,
+// named after the referenced schema's title, to schemas. The name of the type is returned.
+// Each referenced location is only built once: its name is reserved before walking down its properties, so that
+// recursive refs, direct or through other files, find the type name instead of walking forever.
+// Inline schemas, which have no id, are deduplicated like any other nested type instead.
+func (t *transformer) buildRefSchema(ref *reference, schemas *[]Schema) (string, error) {
+	if typeName, ok := t.refs[ref.id]; ok && ref.id != "" {
+		return typeName, nil
+	}
+
+	schema := ref.schema
+	refGraphQL := Schema{TypeName: schema.Title, Description: schema.Description}
+	if ref.id != "" {
+		name, err := t.reserveName(schema.Title)
+		if err != nil {
+			return "", err
 		}
 
-		if schema.Title == "" {
-			schema.Title = definitionName
+		refGraphQL.TypeName = name
+		t.refs[ref.id] = name
+	}
+
+	if schema.Properties != nil {
+		if err := t.walk(schema.Properties, schema.Required, &refGraphQL, schemas, typeRoot, ref.doc); err != nil {
+			return "", fmt.Errorf("error processing ref schema %q: %w", schema.Title, err)
 		}
 	}
 
-	return schema, nil
+	if ref.id == "" {
+		return t.addSchema(schemas, refGraphQL)
+	}
+
+	t.completeSchema(schemas, refGraphQL)
+	return refGraphQL.TypeName, nil
 }
 
-// walkRef generalizes the logic for processing allOf, oneOf, and anyOf refs.
-// Since walk isn't smart enough to know when a ref is being passed down, we manually
-// append the results of the walk to the parent (root) and schemas list.
-func walkRef(schema *jsonschema.Schema, parent *Schema, schemas *[]Schema, schemaPath string) error {
-	refGraphQL := Schema{TypeName: schema.Title, Description: schema.Description}
-	if err := walk(schema.Properties, schema.Required, &refGraphQL, schemas, typeRoot, schema.Definitions, schemaPath); err != nil {
-		return fmt.Errorf("error processing ref schema %q: %w", schema.Title, err)
+// buildRefEnum adds an enum built from a referenced schema to schemas, unless the same location was already
+// built, and returns the name of the enum.
+func (t *transformer) buildRefEnum(ref *reference, schemas *[]Schema) (string, error) {
+	if typeName, ok := t.refs[ref.id]; ok {
+		return typeName, nil
 	}
 
-	// Some refs won't contain titles, in that case borrow from the parent.
-	if schema.Title == "" {
-		schema.Title = parent.TypeName
+	deprecated, err := deprecatedEnumValues(ref.raw)
+	if err != nil {
+		return "", err
 	}
 
-	parent.Fields = append(parent.Fields, Field{
-		Name:        lowerTitle(schema.Title),
-		Description: schema.Description,
-		Type:        typeObject,
-	})
-	*schemas = append(*schemas, refGraphQL)
+	descriptions, err := enumValueDescriptions(ref.raw)
+	if err != nil {
+		return "", err
+	}
 
-	return nil
+	enum, err := t.buildEnum(ref.schema.Title, ref.schema.Description, ref.schema.Enum, deprecated, descriptions)
+	if err != nil {
+		return "", err
+	}
+
+	enumName, err := t.addSchema(schemas, enum)
+	if err != nil {
+		return "", err
+	}
+
+	t.refs[ref.id] = enumName
+	return enumName, nil
 }
 
 func fileNameNoExtension(path string) string {
 	return strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
 }
 
-// title uppercases the first letter of a string, per GraphQL's type naming convention.
-func title(str string) string {
+// toTypeName converts an arbitrary string, such as a kebab-case file name or a title with spaces, into a valid
+// GraphQL name. Any run of characters not allowed in a name acts as a word separator, e.g. "my-schema" becomes "mySchema".
+func toTypeName(str string) string {
+	words := strings.FieldsFunc(str, func(r rune) bool {
+		return !isNameRune(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	name := words[0]
+	for _, word := range words[1:] {
+		name += Title(word)
+	}
+
+	if unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+
+	return name
+}
+
+// isNameRune reports whether r may appear in a GraphQL name.
+func isNameRune(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// Title uppercases the first letter of a string, per GraphQL's type naming convention.
+func Title(str string) string {
 	if str == "" {
 		return str
 	}
@@ -0,0 +1,336 @@
+// Package infer builds JSON schemas out of sample JSON payloads, such as API responses, for when there is no schema to
+// transform into GraphQL.
+package infer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/invopop/jsonschema"
+)
+
+// JSON types a sample value can have.
+const (
+	typeObject  = "object"
+	typeArray   = "array"
+	typeString  = "string"
+	typeNumber  = "number"
+	typeInteger = "integer"
+	typeBoolean = "boolean"
+	typeNull    = "null"
+)
+
+// shape is the merged structure of every sample value found at the same location.
+type shape struct {
+	// types holds the JSON types seen, without null.
+	types map[string]bool
+	// nullable is set once a null value is seen.
+	nullable bool
+	// properties holds the shape of every property of the objects seen by field name, in the order they were first
+	// seen, and keys the sample key each field name was made from.
+	properties *orderedmap.OrderedMap
+	keys       map[string]string
+	// objects is the number of objects seen, and seen the number of those having each property.
+	objects int
+	seen    map[string]int
+	// items is the shape of the items of the arrays seen, nil until an item is seen.
+	items *shape
+}
+
+func newShape() *shape {
+	return &shape{types: map[string]bool{}, properties: orderedmap.New(), keys: map[string]string{}, seen: map[string]int{}}
+}
+
+// InferFiles reads the sample JSON payloads in the files at paths, see Infer.
+func InferFiles(title string, paths ...string) (*jsonschema.Schema, error) {
+	samples := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading sample %q: %w", path, err)
+		}
+		samples = append(samples, contents)
+	}
+
+	return Infer(title, samples...)
+}
+
+// Infer merges the shapes of sample JSON payloads into a JSON schema with the given title, which can be passed to
+// graphql.Transform. Each sample holds one or more JSON values, such as JSON lines, which must be objects or arrays of
+// objects.
+//
+// Properties missing from some of the objects are optional, and properties seen as null are nullable. When a property
+// is seen with different types, its type is widened: integers and numbers become numbers, other mixes of scalars
+// become strings, and anything else becomes a free-form object. Numbers written with a fraction or an exponent, such
+// as 1.0, are numbers rather than integers.
+//
+// Keys that aren't valid GraphQL names are turned into one, e.g. "first-name" into "firstName". Two keys of the same
+// object turning into the same name are an error.
+func Infer(title string, samples ...[]byte) (*jsonschema.Schema, error) {
+	root := newShape()
+	for i, sample := range samples {
+		values, err := decode(sample)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding sample %d: %w", i, err)
+		}
+
+		for _, value := range values {
+			// Arrays at the root, such as list responses, are samples of their items.
+			list, ok := value.([]any)
+			if !ok {
+				list = []any{value}
+			}
+
+			for _, item := range list {
+				if err := root.merge(item); err != nil {
+					return nil, fmt.Errorf("error merging sample %d: %w", i, err)
+				}
+			}
+		}
+	}
+
+	if root.objects == 0 {
+		return nil, errors.New("no sample holds a JSON object, or an array of objects")
+	}
+	if len(root.types) > 1 || root.nullable {
+		return nil, errors.New("every sample must be a JSON object, or an array of objects")
+	}
+
+	document := orderedmap.New()
+	document.Set("$schema", "https://json-schema.org/draft/2020-12/schema")
+	document.Set("title", title)
+	inferred := root.schema()
+	for _, key := range inferred.Keys() {
+		value, _ := inferred.Get(key)
+		document.Set(key, value)
+	}
+
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling inferred schema: %w", err)
+	}
+
+	var schema jsonschema.Schema
+	if err := json.Unmarshal(raw, &schema); err != nil {
+		return nil, fmt.Errorf("error unmarshaling to json schema: %w", err)
+	}
+
+	return &schema, nil
+}
+
+// decode reads every JSON value in a sample. Objects are read into orderedmap.OrderedMap values, keeping the order of
+// their keys, and numbers into json.Number values, keeping how they were written.
+func decode(sample []byte) ([]any, error) {
+	var values []any
+
+	decoder := json.NewDecoder(bytes.NewReader(sample))
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); errors.Is(err, io.EOF) {
+			return values, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		// The raw value is known to be valid, so it can be read token by token.
+		valueDecoder := json.NewDecoder(bytes.NewReader(raw))
+		valueDecoder.UseNumber()
+		value, err := decodeValue(valueDecoder)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+}
+
+// decodeValue reads the next JSON value of decoder, see decode.
+func decodeValue(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := orderedmap.New()
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object.Set(key.(string), value)
+		}
+
+		// Read the closing brace.
+		_, err := decoder.Token()
+		return *object, err
+	case json.Delim('['):
+		list := []any{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+
+		// Read the closing bracket.
+		_, err := decoder.Token()
+		return list, err
+	}
+
+	return token, nil
+}
+
+// fieldName turns a sample key into a GraphQL field name, camel casing the words separated by characters GraphQL
+// names can't hold, e.g. "first-name" into "firstName". Keys holding none of the allowed characters have no name.
+func fieldName(key string) string {
+	words := strings.FieldsFunc(key, func(r rune) bool {
+		return r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	name := words[0]
+	for _, word := range words[1:] {
+		name += strings.ToUpper(word[:1]) + word[1:]
+	}
+
+	if unicode.IsDigit(rune(name[0])) {
+		name = "_" + name
+	}
+
+	return name
+}
+
+// merge adds a sample value to the shape.
+func (s *shape) merge(value any) error {
+	switch value := value.(type) {
+	case nil:
+		s.nullable = true
+	case bool:
+		s.types[typeBoolean] = true
+	case string:
+		s.types[typeString] = true
+	case json.Number:
+		if strings.ContainsAny(string(value), ".eE") {
+			s.types[typeNumber] = true
+		} else {
+			s.types[typeInteger] = true
+		}
+	case []any:
+		s.types[typeArray] = true
+		for _, item := range value {
+			if s.items == nil {
+				s.items = newShape()
+			}
+			if err := s.items.merge(item); err != nil {
+				return err
+			}
+		}
+	case orderedmap.OrderedMap:
+		s.types[typeObject] = true
+		s.objects++
+		for _, key := range value.Keys() {
+			name := fieldName(key)
+			if name == "" {
+				return fmt.Errorf("the key %q can't be turned into a GraphQL field name", key)
+			}
+			if other, ok := s.keys[name]; ok && other != key {
+				return fmt.Errorf("the keys %q and %q both turn into the field name %q", other, key, name)
+			}
+			s.keys[name] = key
+
+			property, ok := s.properties.Get(name)
+			if !ok {
+				property = newShape()
+				s.properties.Set(name, property)
+			}
+
+			item, _ := value.Get(key)
+			if err := property.(*shape).merge(item); err != nil {
+				return fmt.Errorf("error on key %q: %w", key, err)
+			}
+			s.seen[name]++
+		}
+	}
+
+	return nil
+}
+
+// widenedType returns the single JSON type the types seen are widened to, or an empty string when only null was seen.
+func (s *shape) widenedType() string {
+	switch {
+	case len(s.types) == 0:
+		return ""
+	case len(s.types) == 1:
+		for typeName := range s.types {
+			return typeName
+		}
+	case len(s.types) == 2 && s.types[typeInteger] && s.types[typeNumber]:
+		return typeNumber
+	case !s.types[typeObject] && !s.types[typeArray]:
+		return typeString
+	}
+
+	return typeObject
+}
+
+// schema returns the JSON schema of the values the shape was built from.
+func (s *shape) schema() *orderedmap.OrderedMap {
+	schema := orderedmap.New()
+
+	typeName := s.widenedType()
+	mixed := len(s.types) > 1 && typeName == typeObject
+
+	switch {
+	case typeName == "":
+		// Nothing but null was seen, so any value is allowed.
+		schema.Set("type", []any{typeObject, typeNull})
+		return schema
+	case s.nullable:
+		schema.Set("type", []any{typeName, typeNull})
+	default:
+		schema.Set("type", typeName)
+	}
+
+	switch {
+	case mixed:
+		// Objects mixed with other types are left free-form.
+	case typeName == typeArray && s.items != nil:
+		schema.Set("items", *s.items.schema())
+	case typeName == typeObject:
+		properties := orderedmap.New()
+		var required []any
+		for _, key := range s.properties.Keys() {
+			property, _ := s.properties.Get(key)
+			properties.Set(key, *property.(*shape).schema())
+
+			if s.seen[key] == s.objects {
+				required = append(required, key)
+			}
+		}
+
+		if len(properties.Keys()) > 0 {
+			schema.Set("properties", *properties)
+		}
+		if len(required) > 0 {
+			schema.Set("required", required)
+		}
+	}
+
+	return schema
+}
@@ -0,0 +1,151 @@
+package infer
+
+import (
+	"encoding/json"
+	"fmt"
+	"jgschema/graphql"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInfer(t *testing.T) {
+	type test struct {
+		description    string
+		samples        []string
+		wantProperties string
+		wantRequired   []string
+		wantErr        error
+	}
+
+	tests := []test{
+		{
+			description:    "should infer the type of every scalar",
+			samples:        []string{`{"name": "Ada", "age": 36, "height": 1.65, "active": true}`},
+			wantProperties: `{"name":{"type":"string"},"age":{"type":"integer"},"height":{"type":"number"},"active":{"type":"boolean"}}`,
+			wantRequired:   []string{"name", "age", "height", "active"},
+		},
+		{
+			description:    "should make properties missing from some samples optional, and properties seen as null nullable",
+			samples:        []string{`{"id": 1, "note": null}`, `{"id": 2, "note": "hi", "coupon": "SPRING"}`},
+			wantProperties: `{"id":{"type":"integer"},"note":{"type":["string","null"]},"coupon":{"type":"string"}}`,
+			wantRequired:   []string{"id", "note"},
+		},
+		{
+			description: "should widen conflicting types",
+			samples: []string{
+				`{"total": 10, "code": 1, "extra": {"a": 1}, "unknown": null}`,
+				`{"total": 12.5, "code": "A1", "extra": [1]}`,
+			},
+			wantProperties: `{"total":{"type":"number"},"code":{"type":"string"},"extra":{"type":"object"},"unknown":{"type":["object","null"]}}`,
+			wantRequired:   []string{"total", "code", "extra"},
+		},
+		{
+			description:    "should merge nested objects and array items",
+			samples:        []string{`{"lines": [{"sku": "A1"}, {"sku": "B2", "discount": 0.1}], "tags": [], "matrix": [[1, null]]}`},
+			wantProperties: `{"lines":{"type":"array","items":{"type":"object","properties":{"sku":{"type":"string"},"discount":{"type":"number"}},"required":["sku"]}},"tags":{"type":"array"},"matrix":{"type":"array","items":{"type":"array","items":{"type":["integer","null"]}}}}`,
+			wantRequired:   []string{"lines", "tags", "matrix"},
+		},
+		{
+			description:    "should read JSON lines and root arrays as several samples",
+			samples:        []string{"{\"a\": 1}\n{\"b\": 2}", `[{"a": 3}]`},
+			wantProperties: `{"a":{"type":"integer"},"b":{"type":"integer"}}`,
+		},
+		{
+			description:    "should only infer integers for numbers written without a fraction or an exponent",
+			samples:        []string{`{"price": 1.0, "size": 1e3, "count": 3}`},
+			wantProperties: `{"price":{"type":"number"},"size":{"type":"number"},"count":{"type":"integer"}}`,
+			wantRequired:   []string{"price", "size", "count"},
+		},
+		{
+			description:    "should turn keys into valid field names",
+			samples:        []string{`{"first-name": "Ada", "address": {"zip code": "N1"}, "2fa": true}`},
+			wantProperties: `{"firstName":{"type":"string"},"address":{"type":"object","properties":{"zipCode":{"type":"string"}},"required":["zipCode"]},"_2fa":{"type":"boolean"}}`,
+			wantRequired:   []string{"firstName", "address", "_2fa"},
+		},
+		{
+			description: "should fail on keys turning into the same field name",
+			samples:     []string{`{"user": {"first-name": "Ada"}}`, `{"user": {"firstName": "Ada"}}`},
+			wantErr:     fmt.Errorf(`error merging sample 1: error on key "user": the keys "first-name" and "firstName" both turn into the field name "firstName"`),
+		},
+		{
+			description: "should fail on keys without any character of a field name",
+			samples:     []string{`{"$": 1}`},
+			wantErr:     fmt.Errorf(`error merging sample 0: the key "$" can't be turned into a GraphQL field name`),
+		},
+		{
+			description: "should fail on samples that aren't objects",
+			samples:     []string{`{"a": 1}`, `"text"`},
+			wantErr:     fmt.Errorf("every sample must be a JSON object, or an array of objects"),
+		},
+		{
+			description: "should fail without any object",
+			samples:     []string{`[]`},
+			wantErr:     fmt.Errorf("no sample holds a JSON object, or an array of objects"),
+		},
+		{
+			description: "should fail on invalid JSON",
+			samples:     []string{`{"a": }`},
+			wantErr:     fmt.Errorf("error decoding sample 0: invalid character '}' looking for beginning of value"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			samples := make([][]byte, 0, len(test.samples))
+			for _, sample := range test.samples {
+				samples = append(samples, []byte(sample))
+			}
+
+			schema, err := Infer("sample", samples...)
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			properties, err := json.Marshal(schema.Properties)
+			if err != nil {
+				t.Fatalf("error marshaling properties: %v", err)
+			}
+
+			if string(properties) != test.wantProperties {
+				t.Errorf("did not get expected properties.\nwant - %s\ngot - %s", test.wantProperties, properties)
+			}
+
+			if !reflect.DeepEqual(test.wantRequired, schema.Required) {
+				t.Errorf("did not get expected required properties.\nwant - %v\ngot - %v", test.wantRequired, schema.Required)
+			}
+		})
+	}
+}
+
+func TestInferFilesGenerate(t *testing.T) {
+	schema, err := InferFiles("order", "./test_data/order-1.json", "./test_data/order-2.json")
+	if err != nil {
+		t.Fatalf("got the following error when one wasn't expected: %v", err)
+	}
+
+	schemas, err := graphql.TransformWithOptions(schema, "", graphql.Options{RootNameSource: graphql.RootNameFromTitle})
+	if err != nil {
+		t.Fatalf("error transforming inferred schema: %v", err)
+	}
+
+	generated, err := graphql.Generate(schemas)
+	if err != nil {
+		t.Fatalf("error generating graphql schema: %v", err)
+	}
+
+	want, err := os.ReadFile("./test_data/order.graphql")
+	if err != nil {
+		t.Fatalf("error reading graphql schema test file: %v", err)
+	}
+
+	if strings.TrimSpace(string(want)) != generated {
+		t.Errorf("did not get expected result.\nwant - %s\ngot - %s", want, generated)
+	}
+}
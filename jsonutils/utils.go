@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/iancoleman/orderedmap"
 	"github.com/invopop/jsonschema"
 )
 
@@ -21,3 +22,19 @@ func ReadSchema(path string) (*jsonschema.Schema, error) {
 
 	return &schema, nil
 }
+
+// ReadDocument reads the JSON file at path without mapping it onto a jsonschema.Schema, which keeps keywords
+// the jsonschema package doesn't know about, along with the order of every key.
+func ReadDocument(path string) (*orderedmap.OrderedMap, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %w", err)
+	}
+
+	document := orderedmap.New()
+	if err := json.Unmarshal(contents, document); err != nil {
+		return nil, fmt.Errorf("error unmarshaling json document: %w", err)
+	}
+
+	return document, nil
+}
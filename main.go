@@ -2,30 +2,69 @@ package main
 
 import (
 	"fmt"
-	"jgschema/graphql"
-	"jgschema/jsonutils"
 	"os"
 )
 
+// Exit codes returned by the CLI.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+	// exitDrift is returned by the check subcommand when the committed GraphQL schema doesn't match the generated one.
+	exitDrift = 3
+	// exitChanges is returned by the diff subcommand when it finds changes as severe as -fail-on.
+	exitChanges = 4
+)
+
+// version is overridden at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
+const usage = `jgschema converts JSON schemas to GraphQL schemas, and GraphQL schemas back to JSON schemas.
+
+Usage:
+  jgschema <command> [flags] [arguments]
+
+Commands:
+  convert   Convert one or more JSON schemas into a GraphQL schema.
+  check     Verify that one or more JSON schemas can be converted, and optionally match a committed GraphQL schema.
+  reverse   Convert one or more GraphQL schemas into a JSON schema.
+  diff      Classify the changes between two versions of a schema as breaking, dangerous or safe.
+  infer     Infer a JSON schema, and its GraphQL schema, from sample JSON payloads.
+  version   Print the jgschema version.
+
+Run "jgschema <command> -h" for more information about a command.
+`
+
 func main() {
-	schemaPath := "./example.json"
-	jsonSchema, err := jsonutils.ReadSchema(schemaPath)
-	if err != nil {
-		fmt.Printf("error reading JSON schema: %v\n", err)
-		os.Exit(1)
-	}
+	os.Exit(run(os.Args[1:]))
+}
 
-	graphSchema, err := graphql.Transform(jsonSchema, schemaPath)
-	if err != nil {
-		fmt.Printf("error transforming graphql schema: %v", err)
-		os.Exit(1)
+// run dispatches the passed in arguments to the matching subcommand and returns the process exit code.
+func run(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return exitUsage
 	}
 
-	generateSchema, err := graphql.Generate(graphSchema)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	switch args[0] {
+	case "convert":
+		return runConvert(args[1:])
+	case "check":
+		return runCheck(args[1:])
+	case "reverse":
+		return runReverse(args[1:])
+	case "infer":
+		return runInfer(args[1:])
+	case "diff":
+		return runDiff(args[1:])
+	case "version":
+		fmt.Println(version)
+		return exitOK
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return exitOK
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], usage)
+		return exitUsage
 	}
-
-	fmt.Println(generateSchema)
 }
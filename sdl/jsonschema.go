@@ -0,0 +1,351 @@
+package sdl
+
+import (
+	"encoding/json"
+	"fmt"
+	"jgschema/graphql"
+	"os"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// draft is the JSON Schema dialect of the generated documents.
+const draft = "https://json-schema.org/draft/2020-12/schema"
+
+const (
+	// deprecationReasonKeyword and deprecatedEnumValuesKeyword are the keywords the graphql package reads the reasons
+	// of deprecated properties and enum values from, and enumDescriptionsKeyword the one it reads the descriptions of
+	// enum values from.
+	deprecationReasonKeyword    = "x-deprecation-reason"
+	deprecatedEnumValuesKeyword = "x-deprecated-enum-values"
+	enumDescriptionsKeyword     = "x-enum-descriptions"
+)
+
+// Options configures how GraphQL schemas are converted into a JSON schema.
+type Options struct {
+	// Root is the name of the type written at the root of the document, with every other type under "$defs".
+	// Without it, every type is under "$defs".
+	Root string
+	// Formats maps the names of custom scalars to the JSON Schema "format" of the strings they are converted into.
+	// The inverse of graphql.DefaultFormats is used when nil. Other custom scalars allow any value.
+	Formats map[string]string
+}
+
+// converter holds the state of a single ToJSONSchema run.
+type converter struct {
+	opts Options
+	// kinds maps the names of the types of the converted schemas to their kind.
+	kinds map[string]graphql.SchemaKind
+}
+
+// ToJSONSchemaFile converts schemas into a JSON schema, see ToJSONSchema, and writes it into a file with the passed
+// in path and permissions.
+func ToJSONSchemaFile(schemas []graphql.Schema, opts Options, path string, perms os.FileMode) error {
+	document, err := ToJSONSchema(schemas, opts)
+	if err != nil {
+		return err
+	}
+
+	contents, err := json.MarshalIndent(document, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error marshaling json schema: %w", err)
+	}
+
+	return os.WriteFile(path, append(contents, '\n'), perms)
+}
+
+// ToJSONSchema converts GraphQL schemas, such as the ones returned by Parse or graphql.Transform, into a draft 2020-12
+// JSON schema document. Object, interface and input types become objects whose non-null fields are required, enums
+// become string enums and unions a oneOf of their members. Types reference each other through "$defs".
+// Custom scalars aren't written as definitions of their own, fields of a custom scalar are written inline instead.
+func ToJSONSchema(schemas []graphql.Schema, opts Options) (*orderedmap.OrderedMap, error) {
+	if opts.Formats == nil {
+		opts.Formats = map[string]string{}
+		for format, scalar := range graphql.DefaultFormats {
+			opts.Formats[scalar] = format
+		}
+	}
+
+	c := &converter{opts: opts, kinds: map[string]graphql.SchemaKind{}}
+	for _, schema := range schemas {
+		if _, ok := c.kinds[graphql.Title(schema.TypeName)]; ok && schema.Kind != graphql.KindScalar {
+			return nil, fmt.Errorf("type %q is defined more than once", graphql.Title(schema.TypeName))
+		}
+		c.kinds[graphql.Title(schema.TypeName)] = schema.Kind
+	}
+
+	document := orderedmap.New()
+	document.Set("$schema", draft)
+
+	if opts.Root != "" {
+		if kind, ok := c.kinds[graphql.Title(opts.Root)]; !ok || kind == graphql.KindScalar {
+			return nil, fmt.Errorf("root type %q is not defined", opts.Root)
+		}
+	}
+
+	defs := orderedmap.New()
+	for _, schema := range schemas {
+		if schema.Kind == graphql.KindScalar {
+			continue
+		}
+
+		definition, err := c.definition(schema)
+		if err != nil {
+			return nil, fmt.Errorf("error converting type %q: %w", graphql.Title(schema.TypeName), err)
+		}
+
+		if opts.Root == "" || graphql.Title(schema.TypeName) != graphql.Title(opts.Root) {
+			defs.Set(graphql.Title(schema.TypeName), *definition)
+			continue
+		}
+
+		document.Set("title", graphql.Title(schema.TypeName))
+		for _, key := range definition.Keys() {
+			value, _ := definition.Get(key)
+			document.Set(key, value)
+		}
+	}
+
+	if len(defs.Keys()) > 0 {
+		document.Set("$defs", *defs)
+	}
+
+	return document, nil
+}
+
+// definition converts a single schema into the JSON schema it is defined with.
+func (c *converter) definition(schema graphql.Schema) (*orderedmap.OrderedMap, error) {
+	definition := orderedmap.New()
+	if schema.Description != "" {
+		definition.Set("description", schema.Description)
+	}
+
+	switch schema.Kind {
+	case graphql.KindEnum:
+		definition.Set("type", "string")
+
+		values := make([]any, 0, len(schema.Values))
+		descriptions := orderedmap.New()
+		deprecated := orderedmap.New()
+		for _, value := range schema.Values {
+			values = append(values, value.Name)
+			if value.Description != "" {
+				descriptions.Set(value.Name, value.Description)
+			}
+			if value.Deprecated {
+				deprecated.Set(value.Name, value.DeprecationReason)
+			}
+		}
+		definition.Set("enum", values)
+
+		if len(descriptions.Keys()) > 0 {
+			definition.Set(enumDescriptionsKeyword, *descriptions)
+		}
+		if len(deprecated.Keys()) > 0 {
+			definition.Set(deprecatedEnumValuesKeyword, *deprecated)
+		}
+	case graphql.KindUnion:
+		members := make([]any, 0, len(schema.Types))
+		for _, member := range schema.Types {
+			ref, err := c.typeSchema(member)
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, *ref)
+		}
+		definition.Set("oneOf", members)
+	default:
+		definition.Set("type", "object")
+
+		properties := orderedmap.New()
+		required := []any{}
+		for _, field := range schema.Fields {
+			property, err := c.property(field)
+			if err != nil {
+				return nil, fmt.Errorf("error on field %q: %w", field.Name, err)
+			}
+			properties.Set(field.Name, *property)
+
+			if field.Required {
+				required = append(required, field.Name)
+			}
+		}
+		definition.Set("properties", *properties)
+
+		if len(required) > 0 {
+			definition.Set("required", required)
+		}
+	}
+
+	return definition, nil
+}
+
+// property converts a field into the schema of the matching property.
+func (c *converter) property(field graphql.Field) (*orderedmap.OrderedMap, error) {
+	property, err := c.typeSchema(field.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if field.Array {
+		depth := field.ListDepth
+		if depth == 0 {
+			depth = 1
+		}
+
+		// Lists are wrapped from the innermost one outwards.
+		for level := depth - 1; level >= 0; level-- {
+			itemsRequired := level < len(field.ItemsRequired) && field.ItemsRequired[level]
+			if !itemsRequired {
+				property = nullable(property)
+			}
+
+			list := orderedmap.New()
+			list.Set("type", "array")
+			list.Set("items", *property)
+			property = list
+		}
+	}
+
+	if !field.Required {
+		property = nullable(property)
+	}
+
+	// Descriptions can't be set next to a $ref before draft 2019-09, but are fine in 2020-12.
+	if field.Description != "" {
+		property.Set("description", field.Description)
+	}
+
+	if field.Default != "" {
+		value, err := parseLiteral(field.Default)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing default %q: %w", field.Default, err)
+		}
+		property.Set("default", value)
+	}
+
+	for _, constraint := range field.Constraints {
+		value, err := parseLiteral(constraint.Value)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing constraint %s value %q: %w", constraint.Name, constraint.Value, err)
+		}
+		property.Set(constraint.Name, value)
+	}
+
+	if field.Deprecated {
+		property.Set("deprecated", true)
+		if field.DeprecationReason != "" && field.DeprecationReason != field.Description {
+			property.Set(deprecationReasonKeyword, field.DeprecationReason)
+		}
+	}
+
+	return property, nil
+}
+
+// typeSchema returns the schema of a value of the named type, either a JSON type, the inline schema of a custom
+// scalar or a reference to the definition of the type.
+func (c *converter) typeSchema(typeName string) (*orderedmap.OrderedMap, error) {
+	schema := orderedmap.New()
+
+	switch typeName {
+	case "integer", "Int":
+		schema.Set("type", "integer")
+		return schema, nil
+	case "number", "Float":
+		schema.Set("type", "number")
+		return schema, nil
+	case "string", "String", "ID":
+		schema.Set("type", "string")
+		return schema, nil
+	case "boolean", "Boolean":
+		schema.Set("type", "boolean")
+		return schema, nil
+	}
+
+	name := graphql.Title(typeName)
+	kind, ok := c.kinds[name]
+	if !ok {
+		return nil, fmt.Errorf("type %q is not defined", name)
+	}
+
+	if kind == graphql.KindScalar {
+		if format, ok := c.opts.Formats[name]; ok {
+			schema.Set("type", "string")
+			schema.Set("format", format)
+		}
+		return schema, nil
+	}
+
+	if c.opts.Root != "" && name == graphql.Title(c.opts.Root) {
+		schema.Set("$ref", "#")
+		return schema, nil
+	}
+
+	schema.Set("$ref", "#/$defs/"+name)
+	return schema, nil
+}
+
+// nullable returns a schema that lets null through along with the values of schema. A single JSON type gets "null"
+// added to it, while a reference is wrapped into an anyOf with a null schema, since the keywords next to a $ref apply
+// on top of the referenced schema. Other schemas, such as the ones of custom scalars without a format, already allow
+// null and are returned as they are.
+func nullable(schema *orderedmap.OrderedMap) *orderedmap.OrderedMap {
+	if typeName, ok := schema.Get("type"); ok {
+		if str, isString := typeName.(string); isString {
+			schema.Set("type", []any{str, "null"})
+		}
+		return schema
+	}
+
+	if _, ok := schema.Get("$ref"); !ok {
+		return schema
+	}
+
+	null := orderedmap.New()
+	null.Set("type", "null")
+
+	wrapper := orderedmap.New()
+	wrapper.Set("anyOf", []any{*schema, *null})
+	return wrapper
+}
+
+// parseLiteral reads a GraphQL value literal, such as a default value, into the JSON value it stands for. Enum values
+// are read as strings.
+func parseLiteral(source string) (any, error) {
+	p := &parser{lexer: newLexer(source)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	value, err := p.value()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.token.kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+
+	return jsonValue(value), nil
+}
+
+// jsonValue replaces the enum values in a value read by parser.value with strings.
+func jsonValue(value any) any {
+	switch value := value.(type) {
+	case enumLiteral:
+		return string(value)
+	case []any:
+		for i, item := range value {
+			value[i] = jsonValue(item)
+		}
+		return value
+	case orderedmap.OrderedMap:
+		for _, key := range value.Keys() {
+			item, _ := value.Get(key)
+			value.Set(key, jsonValue(item))
+		}
+		return value
+	default:
+		return value
+	}
+}
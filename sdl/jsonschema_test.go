@@ -0,0 +1,115 @@
+package sdl
+
+import (
+	"encoding/json"
+	"fmt"
+	"jgschema/graphql"
+	"os"
+	"testing"
+)
+
+func TestToJSONSchema(t *testing.T) {
+	type test struct {
+		description string
+		inputSchema string
+		opts        Options
+		wantSchema  string // path to test data file
+		wantErr     error
+	}
+
+	schemaTestDir := "./test_data"
+	tests := []test{
+		{
+			description: "should convert every kind of type, with the root type at the root of the document",
+			inputSchema: fmt.Sprintf("%s/schema.graphql", schemaTestDir),
+			opts:        Options{Root: "Person"},
+			wantSchema:  fmt.Sprintf("%s/schema.json", schemaTestDir),
+		},
+		{
+			description: "should put every type under $defs without a root type",
+			inputSchema: fmt.Sprintf("%s/defs.graphql", schemaTestDir),
+			wantSchema:  fmt.Sprintf("%s/defs.json", schemaTestDir),
+		},
+		{
+			description: "should use the configured formats for custom scalars",
+			inputSchema: fmt.Sprintf("%s/defs.graphql", schemaTestDir),
+			opts:        Options{Formats: map[string]string{"Day": "date"}},
+			wantSchema:  fmt.Sprintf("%s/defs-formats.json", schemaTestDir),
+		},
+		{
+			description: "should fail on an unknown root type",
+			inputSchema: fmt.Sprintf("%s/defs.graphql", schemaTestDir),
+			opts:        Options{Root: "Missing"},
+			wantErr:     fmt.Errorf(`root type "Missing" is not defined`),
+		},
+		{
+			description: "should fail on fields of undefined types",
+			inputSchema: fmt.Sprintf("%s/undefined-type.graphql", schemaTestDir),
+			wantErr:     fmt.Errorf(`error converting type "Order": error on field "customer": type "Customer" is not defined`),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			schemas, err := ParseFile(test.inputSchema)
+			if err != nil {
+				t.Fatalf("error reading graphql schema test file at path %q: %v", test.inputSchema, err)
+			}
+
+			document, err := ToJSONSchema(schemas, test.opts)
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			got, err := json.MarshalIndent(document, "", "    ")
+			if err != nil {
+				t.Fatalf("error marshaling json schema: %v", err)
+			}
+
+			want, err := os.ReadFile(test.wantSchema)
+			if err != nil {
+				t.Fatalf("error reading json schema test file at path %q: %v", test.wantSchema, err)
+			}
+
+			if string(want) != string(got)+"\n" {
+				t.Errorf("did not get expected result.\nwant - %s\ngot - %s", want, got)
+			}
+		})
+	}
+}
+
+func TestToJSONSchemaTransformed(t *testing.T) {
+	schemas := []graphql.Schema{
+		{
+			TypeName: "order",
+			Fields: []graphql.Field{
+				{Name: "lines", Type: "line", Array: true, Required: true},
+				{Name: "total", Type: "number"},
+			},
+		},
+		{TypeName: "line", Fields: []graphql.Field{{Name: "sku", Type: "String", Required: true}}},
+	}
+
+	document, err := ToJSONSchema(schemas, Options{Root: "order"})
+	if err != nil {
+		t.Fatalf("got the following error when one wasn't expected: %v", err)
+	}
+
+	got, err := json.Marshal(document)
+	if err != nil {
+		t.Fatalf("error marshaling json schema: %v", err)
+	}
+
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema","title":"Order","type":"object",` +
+		`"properties":{"lines":{"type":"array","items":{"anyOf":[{"$ref":"#/$defs/Line"},{"type":"null"}]}},` +
+		`"total":{"type":["number","null"]}},` +
+		`"required":["lines"],"$defs":{"Line":{"type":"object","properties":{"sku":{"type":"string"}},"required":["sku"]}}}`
+	if string(got) != want {
+		t.Errorf("did not get expected result.\nwant - %s\ngot - %s", want, got)
+	}
+}
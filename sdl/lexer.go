@@ -0,0 +1,265 @@
+package sdl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind identifies the kind of a lexical token of the GraphQL language.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+	tokenBlockString
+)
+
+// token is a single lexical token. For strings, value holds the decoded value rather than the raw source.
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+}
+
+// lexer splits GraphQL source text into tokens, skipping whitespace, commas and comments.
+type lexer struct {
+	source string
+	pos    int
+	line   int
+}
+
+func newLexer(source string) *lexer {
+	return &lexer{source: source, line: 1}
+}
+
+// next returns the next token of the source, or a tokenEOF token once the source is exhausted.
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+	if l.pos >= len(l.source) {
+		return token{kind: tokenEOF, line: l.line}, nil
+	}
+
+	start := l.pos
+	c := l.source[l.pos]
+	switch {
+	case strings.HasPrefix(l.source[l.pos:], "..."):
+		l.pos += 3
+		return token{kind: tokenPunctuator, value: "...", line: l.line}, nil
+	case strings.ContainsRune("!$&():=@[]{}|", rune(c)):
+		l.pos++
+		return token{kind: tokenPunctuator, value: string(c), line: l.line}, nil
+	case isNameStart(c):
+		for l.pos < len(l.source) && isNameContinue(l.source[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenName, value: l.source[start:l.pos], line: l.line}, nil
+	case c == '-' || isDigit(c):
+		return l.number()
+	case strings.HasPrefix(l.source[l.pos:], `"""`):
+		return l.blockString()
+	case c == '"':
+		return l.string()
+	default:
+		return token{}, fmt.Errorf("line %d: unexpected character %q", l.line, c)
+	}
+}
+
+// skipIgnored moves past whitespace, line terminators, commas, comments and byte order marks.
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.source) {
+		switch c := l.source[l.pos]; {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			l.pos++
+		case c == '#':
+			for l.pos < len(l.source) && l.source[l.pos] != '\n' {
+				l.pos++
+			}
+		case strings.HasPrefix(l.source[l.pos:], "\uFEFF"):
+			l.pos += len("\uFEFF")
+		default:
+			return
+		}
+	}
+}
+
+// number lexes an int or a float value.
+func (l *lexer) number() (token, error) {
+	start := l.pos
+	kind := tokenInt
+
+	if l.source[l.pos] == '-' {
+		l.pos++
+	}
+	if !l.digits() {
+		return token{}, fmt.Errorf("line %d: invalid number %q", l.line, l.source[start:l.pos])
+	}
+
+	if l.pos < len(l.source) && l.source[l.pos] == '.' {
+		kind = tokenFloat
+		l.pos++
+		if !l.digits() {
+			return token{}, fmt.Errorf("line %d: invalid number %q", l.line, l.source[start:l.pos])
+		}
+	}
+
+	if l.pos < len(l.source) && (l.source[l.pos] == 'e' || l.source[l.pos] == 'E') {
+		kind = tokenFloat
+		l.pos++
+		if l.pos < len(l.source) && (l.source[l.pos] == '+' || l.source[l.pos] == '-') {
+			l.pos++
+		}
+		if !l.digits() {
+			return token{}, fmt.Errorf("line %d: invalid number %q", l.line, l.source[start:l.pos])
+		}
+	}
+
+	return token{kind: kind, value: l.source[start:l.pos], line: l.line}, nil
+}
+
+// digits moves past a run of digits, reporting whether there was at least one.
+func (l *lexer) digits() bool {
+	start := l.pos
+	for l.pos < len(l.source) && isDigit(l.source[l.pos]) {
+		l.pos++
+	}
+	return l.pos > start
+}
+
+// string lexes a single-line quoted string, decoding its escape sequences.
+func (l *lexer) string() (token, error) {
+	line := l.line
+	l.pos++
+
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.source) || l.source[l.pos] == '\n' || l.source[l.pos] == '\r' {
+			return token{}, fmt.Errorf("line %d: unterminated string", line)
+		}
+
+		c := l.source[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return token{kind: tokenString, value: sb.String(), line: line}, nil
+		case '\\':
+			if l.pos+1 >= len(l.source) {
+				return token{}, fmt.Errorf("line %d: unterminated string", line)
+			}
+
+			escaped := l.source[l.pos+1]
+			l.pos += 2
+			switch escaped {
+			case '"', '\\', '/':
+				sb.WriteByte(escaped)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				if l.pos+4 > len(l.source) {
+					return token{}, fmt.Errorf("line %d: invalid unicode escape", line)
+				}
+				code, err := strconv.ParseUint(l.source[l.pos:l.pos+4], 16, 32)
+				if err != nil {
+					return token{}, fmt.Errorf("line %d: invalid unicode escape %q", line, l.source[l.pos:l.pos+4])
+				}
+				sb.WriteRune(rune(code))
+				l.pos += 4
+			default:
+				return token{}, fmt.Errorf("line %d: invalid escape sequence \\%c", line, escaped)
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.source[l.pos:])
+			sb.WriteRune(r)
+			l.pos += size
+		}
+	}
+}
+
+// blockString lexes a """ block string, and returns its value with the common indentation and the leading and
+// trailing blank lines removed.
+func (l *lexer) blockString() (token, error) {
+	line := l.line
+	l.pos += 3
+
+	var sb strings.Builder
+	for {
+		if l.pos >= len(l.source) {
+			return token{}, fmt.Errorf("line %d: unterminated block string", line)
+		}
+
+		switch rest := l.source[l.pos:]; {
+		case strings.HasPrefix(rest, `"""`):
+			l.pos += 3
+			return token{kind: tokenBlockString, value: blockStringValue(sb.String()), line: line}, nil
+		case strings.HasPrefix(rest, `\"""`):
+			sb.WriteString(`"""`)
+			l.pos += 4
+		default:
+			if rest[0] == '\n' {
+				l.line++
+			}
+			sb.WriteByte(rest[0])
+			l.pos++
+		}
+	}
+}
+
+// blockStringValue implements the BlockStringValue algorithm of the GraphQL specification.
+func blockStringValue(raw string) string {
+	lines := strings.Split(strings.ReplaceAll(strings.ReplaceAll(raw, "\r\n", "\n"), "\r", "\n"), "\n")
+
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < len(line) && (commonIndent == -1 || indent < commonIndent) {
+			commonIndent = indent
+		}
+	}
+
+	if commonIndent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) >= commonIndent {
+				lines[i] = lines[i][commonIndent:]
+			} else {
+				lines[i] = ""
+			}
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimLeft(lines[0], " \t") == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimLeft(lines[len(lines)-1], " \t") == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func isNameStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isNameContinue(c byte) bool {
+	return isNameStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,715 @@
+// Package sdl reads GraphQL schemas written in the schema definition language, and converts them into JSON schemas.
+package sdl
+
+import (
+	"encoding/json"
+	"fmt"
+	"jgschema/graphql"
+	"os"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// builtinTypes maps the built-in GraphQL scalars to the JSON type they are read as, the same way transformed JSON
+// schemas name them. ID has no JSON counterpart and keeps its name.
+var builtinTypes = map[string]string{
+	"Int":     "integer",
+	"Float":   "number",
+	"String":  "string",
+	"Boolean": "boolean",
+}
+
+// enumLiteral is an enum value in a GraphQL value literal, which is written without quotes unlike strings.
+type enumLiteral string
+
+// parser reads the type system definitions of a GraphQL document.
+type parser struct {
+	lexer *lexer
+	token token
+}
+
+// ParseFile reads the GraphQL schema at path, see Parse.
+func ParseFile(path string) ([]graphql.Schema, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading graphql schema: %w", err)
+	}
+
+	schemas, err := Parse(string(contents))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %q: %w", path, err)
+	}
+
+	return schemas, nil
+}
+
+// Parse reads a GraphQL schema into the schemas it defines, in the order they are written in. Fields of the
+// built-in Int, Float, String and Boolean types are given the matching JSON type, as Transform does.
+// Schema definitions, directive definitions, field arguments and directives other than @deprecated and @constraint
+// are read but dropped. Type extensions and executable definitions such as queries aren't supported.
+func Parse(source string) ([]graphql.Schema, error) {
+	p := &parser{lexer: newLexer(source)}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var schemas []graphql.Schema
+	for p.token.kind != tokenEOF {
+		schema, ok, err := p.definition()
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			schemas = append(schemas, schema)
+		}
+	}
+
+	return schemas, nil
+}
+
+// definition reads a single definition, reporting false for definitions that don't turn into a schema.
+func (p *parser) definition() (graphql.Schema, bool, error) {
+	description, err := p.description()
+	if err != nil {
+		return graphql.Schema{}, false, err
+	}
+
+	keyword := p.token
+	if keyword.kind != tokenName {
+		return graphql.Schema{}, false, p.unexpected()
+	}
+
+	switch keyword.value {
+	case "scalar":
+		schema, err := p.scalar()
+		schema.Description = description
+		return schema, true, err
+	case "type":
+		schema, err := p.object(graphql.KindObject)
+		schema.Description = description
+		return schema, true, err
+	case "interface":
+		schema, err := p.object(graphql.KindInterface)
+		schema.Description = description
+		return schema, true, err
+	case "input":
+		schema, err := p.object(graphql.KindInput)
+		schema.Description = description
+		return schema, true, err
+	case "union":
+		schema, err := p.union()
+		schema.Description = description
+		return schema, true, err
+	case "enum":
+		schema, err := p.enum()
+		schema.Description = description
+		return schema, true, err
+	case "schema":
+		return graphql.Schema{}, false, p.schemaDefinition()
+	case "directive":
+		return graphql.Schema{}, false, p.directiveDefinition()
+	case "extend":
+		return graphql.Schema{}, false, fmt.Errorf("line %d: type extensions are not supported", keyword.line)
+	default:
+		return graphql.Schema{}, false, fmt.Errorf("line %d: unexpected %q, expected a type system definition", keyword.line, keyword.value)
+	}
+}
+
+// scalar reads a scalar definition.
+func (p *parser) scalar() (graphql.Schema, error) {
+	if err := p.advance(); err != nil {
+		return graphql.Schema{}, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+
+	if _, err := p.directives(); err != nil {
+		return graphql.Schema{}, err
+	}
+
+	return graphql.Schema{TypeName: name, Kind: graphql.KindScalar}, nil
+}
+
+// object reads the definition of a type, interface or input, which all consist of fields.
+func (p *parser) object(kind graphql.SchemaKind) (graphql.Schema, error) {
+	if err := p.advance(); err != nil {
+		return graphql.Schema{}, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+
+	schema := graphql.Schema{TypeName: name, Kind: kind, Fields: []graphql.Field{}}
+
+	if p.isKeyword("implements") {
+		if err := p.advance(); err != nil {
+			return graphql.Schema{}, err
+		}
+
+		schema.Interfaces, err = p.nameList("&")
+		if err != nil {
+			return graphql.Schema{}, err
+		}
+	}
+
+	if _, err := p.directives(); err != nil {
+		return graphql.Schema{}, err
+	}
+
+	ok, err := p.skipPunctuator("{")
+	if err != nil || !ok {
+		return schema, err
+	}
+
+	for !p.isPunctuator("}") {
+		field, err := p.field(kind == graphql.KindInput)
+		if err != nil {
+			return graphql.Schema{}, fmt.Errorf("error on type %q: %w", name, err)
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+
+	return schema, p.advance()
+}
+
+// field reads a field definition, or an input value definition when input is set, which can have a default value.
+func (p *parser) field(input bool) (graphql.Field, error) {
+	description, err := p.description()
+	if err != nil {
+		return graphql.Field{}, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return graphql.Field{}, err
+	}
+
+	field := graphql.Field{Name: name, Description: description}
+
+	if !input && p.isPunctuator("(") {
+		if err := p.arguments(); err != nil {
+			return graphql.Field{}, fmt.Errorf("error on field %q arguments: %w", name, err)
+		}
+	}
+
+	if err := p.expect(":"); err != nil {
+		return graphql.Field{}, err
+	}
+
+	if err := p.typeRef(&field); err != nil {
+		return graphql.Field{}, fmt.Errorf("error on field %q: %w", name, err)
+	}
+
+	if input && p.isPunctuator("=") {
+		if err := p.advance(); err != nil {
+			return graphql.Field{}, err
+		}
+
+		value, err := p.value()
+		if err != nil {
+			return graphql.Field{}, fmt.Errorf("error on field %q default: %w", name, err)
+		}
+
+		field.Default, err = literal(value)
+		if err != nil {
+			return graphql.Field{}, fmt.Errorf("error on field %q default: %w", name, err)
+		}
+	}
+
+	directives, err := p.directives()
+	if err != nil {
+		return graphql.Field{}, fmt.Errorf("error on field %q: %w", name, err)
+	}
+
+	for _, directive := range directives {
+		switch directive.name {
+		case "deprecated":
+			field.Deprecated = true
+			field.DeprecationReason = directive.reason()
+		case "constraint":
+			for _, key := range directive.args.Keys() {
+				value, _ := directive.args.Get(key)
+				constraint, err := literal(value)
+				if err != nil {
+					return graphql.Field{}, fmt.Errorf("error on field %q constraint %q: %w", name, key, err)
+				}
+				field.Constraints = append(field.Constraints, graphql.Constraint{Name: key, Value: constraint})
+			}
+		}
+	}
+
+	return field, nil
+}
+
+// arguments reads the argument definitions of a field, which are dropped.
+func (p *parser) arguments() error {
+	if err := p.expect("("); err != nil {
+		return err
+	}
+
+	for !p.isPunctuator(")") {
+		if _, err := p.field(true); err != nil {
+			return err
+		}
+	}
+
+	return p.advance()
+}
+
+// typeRef reads a type reference, such as [String!]!, into the type, list and nullability settings of field.
+func (p *parser) typeRef(field *graphql.Field) error {
+	// nonNull holds, from the outermost type inwards, whether each list and the named type are non-null.
+	var nonNull []bool
+	depth := 0
+	for p.isPunctuator("[") {
+		depth++
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+
+	for level := depth; level >= 0; level-- {
+		required, err := p.skipPunctuator("!")
+		if err != nil {
+			return err
+		}
+		nonNull = append([]bool{required}, nonNull...)
+
+		if level > 0 {
+			if err := p.expect("]"); err != nil {
+				return err
+			}
+		}
+	}
+
+	field.Type = name
+	if builtin, ok := builtinTypes[name]; ok {
+		field.Type = builtin
+	}
+
+	field.Required = nonNull[0]
+	if depth == 0 {
+		return nil
+	}
+
+	field.Array = true
+	if depth > 1 {
+		field.ListDepth = depth
+	}
+
+	for level, required := range nonNull[1:] {
+		if required {
+			if field.ItemsRequired == nil {
+				field.ItemsRequired = make([]bool, depth)
+			}
+			field.ItemsRequired[level] = true
+		}
+	}
+
+	return nil
+}
+
+// union reads a union definition.
+func (p *parser) union() (graphql.Schema, error) {
+	if err := p.advance(); err != nil {
+		return graphql.Schema{}, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+
+	if _, err := p.directives(); err != nil {
+		return graphql.Schema{}, err
+	}
+
+	schema := graphql.Schema{TypeName: name, Kind: graphql.KindUnion}
+
+	ok, err := p.skipPunctuator("=")
+	if err != nil || !ok {
+		return schema, err
+	}
+
+	schema.Types, err = p.nameList("|")
+	if err != nil {
+		return graphql.Schema{}, fmt.Errorf("error on union %q: %w", name, err)
+	}
+
+	return schema, nil
+}
+
+// enum reads an enum definition, along with the description and deprecation of every value.
+func (p *parser) enum() (graphql.Schema, error) {
+	if err := p.advance(); err != nil {
+		return graphql.Schema{}, err
+	}
+
+	name, err := p.name()
+	if err != nil {
+		return graphql.Schema{}, err
+	}
+
+	if _, err := p.directives(); err != nil {
+		return graphql.Schema{}, err
+	}
+
+	schema := graphql.Schema{TypeName: name, Kind: graphql.KindEnum}
+
+	ok, err := p.skipPunctuator("{")
+	if err != nil || !ok {
+		return schema, err
+	}
+
+	for !p.isPunctuator("}") {
+		valueDescription, err := p.description()
+		if err != nil {
+			return graphql.Schema{}, err
+		}
+
+		valueName, err := p.name()
+		if err != nil {
+			return graphql.Schema{}, fmt.Errorf("error on enum %q: %w", name, err)
+		}
+
+		directives, err := p.directives()
+		if err != nil {
+			return graphql.Schema{}, fmt.Errorf("error on enum %q value %q: %w", name, valueName, err)
+		}
+
+		value := graphql.EnumValue{Name: valueName, Description: valueDescription}
+		for _, directive := range directives {
+			if directive.name == "deprecated" {
+				value.Deprecated = true
+				value.DeprecationReason = directive.reason()
+			}
+		}
+		schema.Values = append(schema.Values, value)
+	}
+
+	return schema, p.advance()
+}
+
+// schemaDefinition reads a schema definition, which only lists the root operation types.
+func (p *parser) schemaDefinition() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	if _, err := p.directives(); err != nil {
+		return err
+	}
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+
+	for !p.isPunctuator("}") {
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if _, err := p.name(); err != nil {
+			return err
+		}
+	}
+
+	return p.advance()
+}
+
+// directiveDefinition reads the definition of a directive, such as the one generated for @constraint.
+func (p *parser) directiveDefinition() error {
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	if err := p.expect("@"); err != nil {
+		return err
+	}
+
+	if _, err := p.name(); err != nil {
+		return err
+	}
+
+	if p.isPunctuator("(") {
+		if err := p.arguments(); err != nil {
+			return err
+		}
+	}
+
+	if p.isKeyword("repeatable") {
+		if err := p.advance(); err != nil {
+			return err
+		}
+	}
+
+	if !p.isKeyword("on") {
+		return p.unexpected()
+	}
+
+	if err := p.advance(); err != nil {
+		return err
+	}
+
+	_, err := p.nameList("|")
+	return err
+}
+
+// directive is a directive applied to a definition, along with the values of its arguments.
+type directive struct {
+	name string
+	args *orderedmap.OrderedMap
+}
+
+// reason returns the reason argument of a @deprecated directive.
+func (d directive) reason() string {
+	reason, _ := d.args.Get("reason")
+	str, _ := reason.(string)
+	return str
+}
+
+// directives reads the directives applied to a definition, if any.
+func (p *parser) directives() ([]directive, error) {
+	var directives []directive
+	for p.isPunctuator("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+
+		d := directive{name: name, args: orderedmap.New()}
+		if ok, err := p.skipPunctuator("("); err != nil {
+			return nil, err
+		} else if ok {
+			for !p.isPunctuator(")") {
+				arg, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err := p.expect(":"); err != nil {
+					return nil, err
+				}
+
+				value, err := p.value()
+				if err != nil {
+					return nil, fmt.Errorf("error on @%s argument %q: %w", name, arg, err)
+				}
+				d.args.Set(arg, value)
+			}
+
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+		}
+
+		directives = append(directives, d)
+	}
+
+	return directives, nil
+}
+
+// value reads a constant value literal. Numbers are read as json.Number, enum values as enumLiteral, lists as []any
+// and objects as orderedmap.OrderedMap, the same types JSON documents are read into.
+func (p *parser) value() (any, error) {
+	tok := p.token
+	switch {
+	case tok.kind == tokenInt || tok.kind == tokenFloat:
+		return json.Number(tok.value), p.advance()
+	case tok.kind == tokenString || tok.kind == tokenBlockString:
+		return tok.value, p.advance()
+	case tok.kind == tokenName:
+		var value any
+		switch tok.value {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		case "null":
+			value = nil
+		default:
+			value = enumLiteral(tok.value)
+		}
+		return value, p.advance()
+	case p.isPunctuator("["):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		list := []any{}
+		for !p.isPunctuator("]") {
+			item, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, p.advance()
+	case p.isPunctuator("{"):
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		object := orderedmap.New()
+		for !p.isPunctuator("}") {
+			key, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+
+			value, err := p.value()
+			if err != nil {
+				return nil, err
+			}
+			object.Set(key, value)
+		}
+		return *object, p.advance()
+	default:
+		return nil, p.unexpected()
+	}
+}
+
+// description reads the optional description preceding a definition.
+func (p *parser) description() (string, error) {
+	if p.token.kind != tokenString && p.token.kind != tokenBlockString {
+		return "", nil
+	}
+
+	description := p.token.value
+	return description, p.advance()
+}
+
+// name reads a name.
+func (p *parser) name() (string, error) {
+	if p.token.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.token.value
+	return name, p.advance()
+}
+
+// nameList reads a list of names separated by separator, which may also precede the first name.
+func (p *parser) nameList(separator string) ([]string, error) {
+	if _, err := p.skipPunctuator(separator); err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+
+		ok, err := p.skipPunctuator(separator)
+		if err != nil || !ok {
+			return names, err
+		}
+	}
+}
+
+// advance moves to the next token.
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+
+	p.token = tok
+	return nil
+}
+
+// expect moves past the punctuator value, failing when the current token is anything else.
+func (p *parser) expect(value string) error {
+	if !p.isPunctuator(value) {
+		return p.unexpected()
+	}
+
+	return p.advance()
+}
+
+// skipPunctuator moves past the punctuator value when it is the current token, and reports whether it was.
+func (p *parser) skipPunctuator(value string) (bool, error) {
+	if !p.isPunctuator(value) {
+		return false, nil
+	}
+
+	return true, p.advance()
+}
+
+func (p *parser) isPunctuator(value string) bool {
+	return p.token.kind == tokenPunctuator && p.token.value == value
+}
+
+func (p *parser) isKeyword(value string) bool {
+	return p.token.kind == tokenName && p.token.value == value
+}
+
+// unexpected returns the error for a token that isn't allowed where it was found.
+func (p *parser) unexpected() error {
+	if p.token.kind == tokenEOF {
+		return fmt.Errorf("line %d: unexpected end of document", p.token.line)
+	}
+
+	return fmt.Errorf("line %d: unexpected %q", p.token.line, p.token.value)
+}
+
+// literal writes a value read by value back as a GraphQL value literal.
+func literal(value any) (string, error) {
+	switch value := value.(type) {
+	case enumLiteral:
+		return string(value), nil
+	case []any:
+		items := make([]string, 0, len(value))
+		for _, item := range value {
+			str, err := literal(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, str)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	case orderedmap.OrderedMap:
+		fields := make([]string, 0, len(value.Keys()))
+		for _, key := range value.Keys() {
+			raw, _ := value.Get(key)
+			str, err := literal(raw)
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s", key, str))
+		}
+		return "{" + strings.Join(fields, ", ") + "}", nil
+	default:
+		// Scalars are written the same way in JSON, which GraphQL string escapes are a superset of.
+		var sb strings.Builder
+		encoder := json.NewEncoder(&sb)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(value); err != nil {
+			return "", err
+		}
+		return strings.TrimSuffix(sb.String(), "\n"), nil
+	}
+}
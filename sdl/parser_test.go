@@ -0,0 +1,236 @@
+package sdl
+
+import (
+	"fmt"
+	"jgschema/graphql"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	type test struct {
+		description string
+		input       string
+		wantGraphQL []graphql.Schema
+		wantErr     error
+	}
+
+	tests := []test{
+		{
+			description: "should read object types with built-in scalars as JSON types",
+			input: `
+				"A person."
+				type Person {
+					"Name of the person."
+					name: String!
+					age: Int
+					height: Float
+					active: Boolean
+					id: ID!
+				}`,
+			wantGraphQL: []graphql.Schema{
+				{
+					TypeName:    "Person",
+					Description: "A person.",
+					Fields: []graphql.Field{
+						{Name: "name", Type: "string", Description: "Name of the person.", Required: true},
+						{Name: "age", Type: "integer"},
+						{Name: "height", Type: "number"},
+						{Name: "active", Type: "boolean"},
+						{Name: "id", Type: "ID", Required: true},
+					},
+				},
+			},
+		},
+		{
+			description: "should read list and non-null wrappers",
+			input: `type Lists {
+				tags: [String]
+				names: [String!]!
+				matrix: [[Float!]]!
+			}`,
+			wantGraphQL: []graphql.Schema{
+				{
+					TypeName: "Lists",
+					Fields: []graphql.Field{
+						{Name: "tags", Type: "string", Array: true},
+						{Name: "names", Type: "string", Array: true, Required: true, ItemsRequired: []bool{true}},
+						{Name: "matrix", Type: "number", Array: true, Required: true, ListDepth: 2, ItemsRequired: []bool{false, true}},
+					},
+				},
+			},
+		},
+		{
+			description: "should read interfaces, unions, enums, scalars and inputs",
+			input: `
+				scalar DateTime
+				interface Node { id: ID! }
+				type Dog implements Node & Pet { id: ID! }
+				union Animal = | Dog | Cat
+				enum Species { "A dog." DOG CAT @deprecated(reason: "Gone.") HAMSTER @deprecated }
+				input Filter { limit: Int = 20, species: [Species] = [DOG, CAT], since: DateTime }`,
+			wantGraphQL: []graphql.Schema{
+				{TypeName: "DateTime", Kind: graphql.KindScalar},
+				{
+					TypeName: "Node",
+					Kind:     graphql.KindInterface,
+					Fields:   []graphql.Field{{Name: "id", Type: "ID", Required: true}},
+				},
+				{
+					TypeName:   "Dog",
+					Interfaces: []string{"Node", "Pet"},
+					Fields:     []graphql.Field{{Name: "id", Type: "ID", Required: true}},
+				},
+				{TypeName: "Animal", Kind: graphql.KindUnion, Types: []string{"Dog", "Cat"}},
+				{
+					TypeName: "Species",
+					Kind:     graphql.KindEnum,
+					Values: []graphql.EnumValue{
+						{Name: "DOG", Description: "A dog."},
+						{Name: "CAT", Deprecated: true, DeprecationReason: "Gone."},
+						{Name: "HAMSTER", Deprecated: true},
+					},
+				},
+				{
+					TypeName: "Filter",
+					Kind:     graphql.KindInput,
+					Fields: []graphql.Field{
+						{Name: "limit", Type: "integer", Default: "20"},
+						{Name: "species", Type: "Species", Array: true, Default: "[DOG, CAT]"},
+						{Name: "since", Type: "DateTime"},
+					},
+				},
+			},
+		},
+		{
+			description: "should read @deprecated and @constraint, and drop arguments, schema and directive definitions",
+			input: `
+				directive @constraint(minLength: Int, pattern: String) on FIELD_DEFINITION | INPUT_FIELD_DEFINITION
+				schema { query: Query }
+				type Query {
+					search(text: String! = "a \"b\"", first: Int): [String] @deprecated(reason: "Use find.")
+					code: String @constraint(minLength: 2, pattern: "^[A-Z]+$") @cacheControl(maxAge: 60)
+				}`,
+			wantGraphQL: []graphql.Schema{
+				{
+					TypeName: "Query",
+					Fields: []graphql.Field{
+						{Name: "search", Type: "string", Array: true, Deprecated: true, DeprecationReason: "Use find."},
+						{
+							Name: "code",
+							Type: "string",
+							Constraints: []graphql.Constraint{
+								{Name: "minLength", Value: "2"},
+								{Name: "pattern", Value: `"^[A-Z]+$"`},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "should read block string descriptions without their common indentation",
+			input: `
+				"""
+				First line.
+				  Indented.
+				"""
+				type Doc { text: String }`,
+			wantGraphQL: []graphql.Schema{
+				{
+					TypeName:    "Doc",
+					Description: "First line.\n  Indented.",
+					Fields:      []graphql.Field{{Name: "text", Type: "string"}},
+				},
+			},
+		},
+		{
+			description: "should fail on type extensions",
+			input:       "type A { b: Int }\nextend type A { c: Int }",
+			wantErr:     fmt.Errorf("line 2: type extensions are not supported"),
+		},
+		{
+			description: "should fail on queries",
+			input:       "query { a }",
+			wantErr:     fmt.Errorf(`line 1: unexpected "query", expected a type system definition`),
+		},
+		{
+			description: "should fail on unterminated types",
+			input:       "type A {\n\tb: [Int\n}",
+			wantErr:     fmt.Errorf(`error on type "A": error on field "b": line 3: unexpected "}"`),
+		},
+		{
+			description: "should fail on unterminated strings",
+			input:       `"A description`,
+			wantErr:     fmt.Errorf("line 1: unterminated string"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			schemas, err := Parse(test.input)
+			if test.wantErr != nil {
+				if err == nil || err.Error() != test.wantErr.Error() {
+					t.Fatalf("did not get the expected error.\nwant- %v\ngot - %v", test.wantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("got the following error when one wasn't expected: %v", err)
+			}
+
+			for i := range schemas {
+				if len(schemas[i].Fields) == 0 {
+					schemas[i].Fields = nil
+				}
+			}
+
+			if !reflect.DeepEqual(test.wantGraphQL, schemas) {
+				t.Errorf("did not get expected result.\nwant - %+v\ngot - %+v", test.wantGraphQL, schemas)
+			}
+		})
+	}
+}
+
+func TestParseGenerated(t *testing.T) {
+	schemas := []graphql.Schema{
+		{TypeName: "Date", Kind: graphql.KindScalar},
+		{
+			TypeName:    "event",
+			Description: "An event.\n\nWith a long description.",
+			Fields: []graphql.Field{
+				{Name: "name", Type: "string", Required: true, Constraints: []graphql.Constraint{{Name: "maxLength", Value: "10"}}},
+				{Name: "on", Type: "Date"},
+				{Name: "old", Type: "boolean", Deprecated: true, DeprecationReason: `Say "no".`},
+			},
+		},
+		{
+			TypeName: "eventInput",
+			Kind:     graphql.KindInput,
+			Fields:   []graphql.Field{{Name: "limit", Type: "integer", Default: "20"}},
+		},
+		{
+			TypeName: "status",
+			Kind:     graphql.KindEnum,
+			Values:   []graphql.EnumValue{{Name: "OPEN"}, {Name: "CLOSED", Description: "Done with."}},
+		},
+	}
+
+	generated, err := graphql.Generate(schemas)
+	if err != nil {
+		t.Fatalf("error generating graphql schema: %v", err)
+	}
+
+	parsed, err := Parse(generated)
+	if err != nil {
+		t.Fatalf("error parsing generated graphql schema: %v", err)
+	}
+
+	// Type names are generated with an uppercase first letter.
+	for i := range schemas {
+		schemas[i].TypeName = graphql.Title(schemas[i].TypeName)
+	}
+
+	if !reflect.DeepEqual(schemas, parsed) {
+		t.Errorf("did not read back the generated schemas.\nwant - %+v\ngot - %+v", schemas, parsed)
+	}
+}